@@ -44,13 +44,58 @@ type AppError interface {
 	Build(Language, ...InfoBuilderOption) *InfoBuilderResult
 }
 
+// HeaderAppError is implemented by AppErrors supporting custom HTTP response headers.
+// It is separated from AppError so that other implementations of AppError keep working,
+// use the function WithHeader to set headers on any AppError.
+type HeaderAppError interface {
+	AppError
+
+	// Headers gets custom HTTP response headers
+	Headers() map[string]string
+	// WithHeader sets a custom HTTP response header, it overrides the one in the config
+	WithHeader(k string, v string) AppError
+}
+
+// WithHeader sets a custom HTTP response header on the error if it implements HeaderAppError,
+// otherwise the error is returned unchanged
+func WithHeader(err AppError, k string, v string) AppError {
+	if headerErr, ok := err.(HeaderAppError); ok {
+		return headerErr.WithHeader(k, v)
+	}
+	return err
+}
+
+// ParamFormatAppError is implemented by AppErrors supporting custom format hints of params.
+// It is separated from AppError so that other implementations of AppError keep working,
+// use the function WithParamFormat to set format hints on any AppError.
+type ParamFormatAppError interface {
+	AppError
+
+	// ParamFormats gets custom format hints of params
+	ParamFormats() map[string]ParamFormat
+	// WithParamFormat sets format hint of a param, it overrides the one in the config
+	WithParamFormat(k string, format ParamFormat) AppError
+}
+
+// WithParamFormat sets format hint of a param on the error if it implements ParamFormatAppError,
+// otherwise the error is returned unchanged
+func WithParamFormat(err AppError, k string, format ParamFormat) AppError {
+	if formatErr, ok := err.(ParamFormatAppError); ok {
+		return formatErr.WithParamFormat(k, format)
+	}
+	return err
+}
+
 // defaultAppError implements AppError interface
 type defaultAppError struct {
+	manager       *Manager
 	err           error
 	cause         error
 	params        map[string]any
 	transParams   map[string]string
 	debug         string
+	headers       map[string]string
+	paramFormats  map[string]ParamFormat
 	customConfig  *ErrorConfig
 	customBuilder InfoBuilderFunc
 
@@ -95,6 +140,14 @@ func (e *defaultAppError) Debug() string {
 	return e.debug
 }
 
+func (e *defaultAppError) Headers() map[string]string {
+	return e.headers
+}
+
+func (e *defaultAppError) ParamFormats() map[string]ParamFormat {
+	return e.paramFormats
+}
+
 func (e *defaultAppError) CustomConfig() *ErrorConfig {
 	return e.customConfig
 }
@@ -113,20 +166,36 @@ func (e *defaultAppError) WithTransParam(k string, v string) AppError {
 	return e
 }
 
+func (e *defaultAppError) WithHeader(k string, v string) AppError {
+	if e.headers == nil {
+		e.headers = map[string]string{}
+	}
+	e.headers[k] = v
+	return e
+}
+
+func (e *defaultAppError) WithParamFormat(k string, format ParamFormat) AppError {
+	if e.paramFormats == nil {
+		e.paramFormats = map[string]ParamFormat{}
+	}
+	e.paramFormats[k] = format
+	return e
+}
+
 func (e *defaultAppError) WithCause(cause error) AppError {
 	e.cause = cause
 	return e
 }
 
 func (e *defaultAppError) WithDebug(format string, args ...any) AppError {
-	if !globalConfig.Debug {
+	if !e.manager.config.Debug {
 		return e
 	}
 	msg := fmt.Sprintf(format, args...)
 	if e.debug == "" {
 		e.debug = msg
 	} else {
-		e.debug = e.debug + globalConfig.MultiErrorSeparator + msg
+		e.debug = e.debug + e.manager.config.MultiErrorSeparator + msg
 	}
 	return e
 }
@@ -149,34 +218,40 @@ func (e *defaultAppError) Config() *ErrorConfig {
 	if e.customConfig != nil {
 		return e.customConfig
 	}
-	return GetErrorConfig(e.err)
+	return e.manager.GetErrorConfig(e.err)
 }
 
 // BuildConfig builds config for building info from the error
 func (e *defaultAppError) BuildConfig(lang Language, options ...InfoBuilderOption) *InfoBuilderConfig {
 	errCfg := e.Config()
+	if errCfg == nil && !e.disallowGlobalConfigMapping {
+		// Fall back to the config provided by the errors via interfaces
+		errCfg = discoverErrorConfig(e.err)
+	}
 	if errCfg == nil {
 		errCfg = &ErrorConfig{}
 	}
-	// Copy config to a struct object
-	errCfgObj := *errCfg
+	// Copy config to a struct object with inheriting from the parent configs
+	errCfgObj := e.manager.registry.Resolve(errCfg)
 	if errCfgObj.Status == 0 {
-		errCfgObj.Status = globalConfig.DefaultErrorStatus
+		errCfgObj.Status = e.manager.config.DefaultErrorStatus
 	}
 	if errCfgObj.Code == "" {
 		errCfgObj.Code = UnwrapToRoot(e.err).Error()
 	}
 	if errCfgObj.LogLevel == LogLevelNone {
-		errCfgObj.LogLevel = globalConfig.DefaultLogLevel
+		errCfgObj.LogLevel = e.manager.config.DefaultLogLevel
 	}
 	buildCfg := &InfoBuilderConfig{
-		ErrorConfig:     errCfgObj,
-		InfoBuilderFunc: e.customBuilder,
-		Language:        lang,
-		ErrorSeparator:  globalConfig.MultiErrorSeparator,
-		TranslationFunc: globalConfig.TranslationFunc,
-		TranslateTitle:  true,
-		FallbackToErrorContentOnMissingTranslation: globalConfig.FallbackToErrorContentOnMissingTranslation,
+		ErrorConfig:       errCfgObj,
+		InfoBuilderFunc:   e.customBuilder,
+		Language:          lang,
+		ErrorSeparator:    e.manager.config.MultiErrorSeparator,
+		FallbackLanguages: LanguageFallbacks(lang, e.manager.config.DefaultLanguage),
+		TranslationFunc:   e.manager.config.TranslationFunc,
+		TranslateTitle:    true,
+		FormatParams:      true,
+		FallbackToErrorContentOnMissingTranslation: e.manager.config.FallbackToErrorContentOnMissingTranslation,
 	}
 	for _, opt := range options {
 		opt(buildCfg)
@@ -206,13 +281,19 @@ func (e *defaultAppError) build(buildCfg *InfoBuilderConfig) *InfoBuilderResult
 	errInfo.Status = errCfg.Status
 	errInfo.Code = errCfg.Code
 	errInfo.LogLevel = errCfg.LogLevel
+	errInfo.Extra = errCfg.Extra
+	errInfo.GRPCCode = errCfg.GRPCCode
 
 	message, title := e.buildMessage(buildCfg, buildResult)
 	errInfo.Message = message
 	errInfo.Title = title
+	if len(e.params) > 0 {
+		errInfo.Params = e.params
+	}
+	errInfo.Headers = e.buildHeaders(errCfg.Headers)
 
 	// In non-debug mode, output fields `Debug` and `Cause` are set empty
-	if globalConfig.Debug {
+	if e.manager.config.Debug {
 		errInfo.Debug = e.debug
 		if e.cause != nil {
 			errInfo.Cause = e.cause.Error()
@@ -245,7 +326,8 @@ func (e *defaultAppError) buildMessage(buildCfg *InfoBuilderConfig, result *Info
 		transKey = UnwrapToRoot(e.err).Error()
 	}
 
-	msg, err := buildCfg.TranslationFunc(buildCfg.Language, transKey, params)
+	msg, lang, err := translate(buildCfg, transKey, params)
+	result.Language = lang
 	if err != nil {
 		result.TransMissingMainKey = true
 		result.TransMissingKeys = append(result.TransMissingKeys, transKey)
@@ -255,7 +337,7 @@ func (e *defaultAppError) buildMessage(buildCfg *InfoBuilderConfig, result *Info
 	}
 
 	if buildCfg.TranslateTitle && title != "" {
-		transTitle, err := buildCfg.TranslationFunc(buildCfg.Language, title, params)
+		transTitle, _, err := translate(buildCfg, title, params)
 		if err != nil {
 			result.TransMissingKeys = append(result.TransMissingKeys, title)
 		}
@@ -264,33 +346,64 @@ func (e *defaultAppError) buildMessage(buildCfg *InfoBuilderConfig, result *Info
 	return msg, title
 }
 
-// buildParams builds param map from params and translating params
+// buildHeaders builds HTTP response headers from the config and the custom headers,
+// placeholders in the header values are replaced by the params
+func (e *defaultAppError) buildHeaders(cfgHeaders map[string]string) http.Header {
+	if len(cfgHeaders) == 0 && len(e.headers) == 0 {
+		return nil
+	}
+	lookup := func(name string) (string, bool) {
+		if v, exists := e.params[name]; exists {
+			return fmt.Sprint(v), true
+		}
+		v, exists := e.transParams[name]
+		return v, exists
+	}
+	headers := make(http.Header, len(cfgHeaders)+len(e.headers))
+	for k, v := range cfgHeaders {
+		headers.Set(k, expandPlaceholders(v, lookup))
+	}
+	for k, v := range e.headers {
+		headers.Set(k, expandPlaceholders(v, lookup))
+	}
+	return headers
+}
+
+// buildParams builds param map from params and translating params.
+// Params are formatted for the build language if param formatting is enabled.
 func (e *defaultAppError) buildParams(buildCfg *InfoBuilderConfig, result *InfoBuilderResult) map[string]any {
 	params := e.params
 	for k, v := range e.transParams {
-		if translated, err := buildCfg.TranslationFunc(buildCfg.Language, v, nil); err != nil {
+		if translated, _, err := translate(buildCfg, v, nil); err != nil {
 			result.TransMissingKeys = append(result.TransMissingKeys, v)
 			params[k] = v
 		} else {
 			params[k] = translated
 		}
 	}
-	return params
+	if !buildCfg.FormatParams {
+		return params
+	}
+	return formatParams(params, buildCfg.Language, buildCfg.ErrorConfig.ParamFormats, e.paramFormats)
 }
 
-// newDefaultAppError creates *defaultAppError
-func newDefaultAppError(err error) *defaultAppError {
-	return &defaultAppError{
-		err:         Wrap(err),
-		params:      map[string]any{},
-		transParams: map[string]string{},
+// translate translates the key in the build language, then in the fallback languages in order.
+// It returns the translation and the language used, or the error of the build language if the key
+// is missing in all the languages.
+func translate(buildCfg *InfoBuilderConfig, key string, params map[string]any) (string, Language, error) {
+	msg, err := buildCfg.TranslationFunc(buildCfg.Language, key, params)
+	if err == nil {
+		return msg, buildCfg.Language, nil
 	}
+	for _, lang := range buildCfg.FallbackLanguages {
+		if msg, fallbackErr := buildCfg.TranslationFunc(lang, key, params); fallbackErr == nil {
+			return msg, lang, nil
+		}
+	}
+	return msg, nil, err
 }
 
 // New creates an AppError containing the given error
 func New(err error) AppError {
-	if err == nil {
-		return nil
-	}
-	return newDefaultAppError(err)
+	return defaultManager.New(err)
 }
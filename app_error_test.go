@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -298,3 +299,175 @@ func Test_AppError_Build(t *testing.T) {
 		assert.Equal(t, 0, len(errInfo.InnerErrors))
 	})
 }
+
+func Test_AppError_Build_LanguageFallback(t *testing.T) {
+	messages := map[string]map[string]string{
+		"fr": {"ErrTest1": "Erreur {kk1}", "Internal Server Error": "Erreur interne"},
+		"en": {"ErrTest1": "Error {kk1}", "Internal Server Error": "Internal error", "vv1": "value"},
+	}
+	translate := func(lang Language, key string, params map[string]any) (string, error) {
+		msg, exists := messages[fmt.Sprint(lang)][key]
+		if !exists {
+			return "", errMissingTrans
+		}
+		return expandPlaceholders(msg, func(name string) (string, bool) {
+			v, exists := params[name]
+			return fmt.Sprint(v), exists
+		}), nil
+	}
+	m := NewManager(&Config{
+		DefaultLanguage: LanguageEn,
+		TranslationFunc: translate,
+		FallbackToErrorContentOnMissingTranslation: true,
+	})
+
+	t.Run("fall back to parent and default languages", func(t *testing.T) {
+		buildRes := m.New(errTest1).WithTransParam("kk1", "vv1").Build("fr-CH")
+		assert.Equal(t, "fr", buildRes.Language)
+		assert.Equal(t, "Erreur value", buildRes.ErrorInfo.Message)
+		assert.Equal(t, "Erreur interne", buildRes.ErrorInfo.Title)
+		assert.Nil(t, buildRes.TransMissingKeys)
+		assert.False(t, buildRes.TransMissingMainKey)
+
+		buildRes = m.New(errTest1).Build("de-AT")
+		assert.Equal(t, LanguageEn, buildRes.Language)
+		assert.Equal(t, "Error {kk1}", buildRes.ErrorInfo.Message)
+		assert.Equal(t, "Internal error", buildRes.ErrorInfo.Title)
+	})
+
+	t.Run("custom fallback languages", func(t *testing.T) {
+		buildRes := m.New(errTest1).Build("de", InfoBuilderOptionFallbackLanguages("fr"))
+		assert.Equal(t, "fr", buildRes.Language)
+		assert.Equal(t, "Erreur {kk1}", buildRes.ErrorInfo.Message)
+
+		buildRes = m.New(errTest1).Build("fr-CH", InfoBuilderOptionFallbackLanguages())
+		assert.Nil(t, buildRes.Language)
+		assert.True(t, buildRes.TransMissingMainKey)
+		assert.Equal(t, []string{"ErrTest1", "Internal Server Error"}, buildRes.TransMissingKeys)
+		assert.Equal(t, "ErrTest1", buildRes.ErrorInfo.Message)
+	})
+
+	t.Run("requested language used", func(t *testing.T) {
+		buildRes := m.New(errTest1).Build("fr")
+		assert.Equal(t, "fr", buildRes.Language)
+		assert.Equal(t, "Erreur {kk1}", buildRes.ErrorInfo.Message)
+	})
+}
+
+func Test_AppError_Headers(t *testing.T) {
+	t.Run("headers from config and instance", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errRateLimited := m.Create("ErrRateLimited", &ErrorConfig{
+			Status: 429,
+			Headers: map[string]string{
+				"retry-after":      "{retryAfter}",
+				"X-RateLimit-Info": "limit={limit}, unknown={unknown}",
+			},
+		})
+
+		ae := m.New(errRateLimited).
+			WithParam("retryAfter", 30).
+			WithTransParam("limit", "100")
+		ae = WithHeader(WithHeader(ae, "X-Request-ID", "req-1"), "X-RateLimit-Info", "limit={limit}")
+		assert.Equal(t, map[string]string{"X-Request-ID": "req-1", "X-RateLimit-Info": "limit={limit}"},
+			ae.(HeaderAppError).Headers())
+
+		errInfo := ae.Build(LanguageEn).ErrorInfo
+		assert.Equal(t, "30", errInfo.Headers.Get("Retry-After"))
+		assert.Equal(t, "limit=100", errInfo.Headers.Get("X-RateLimit-Info"))
+		assert.Equal(t, "req-1", errInfo.Headers.Get("X-Request-ID"))
+	})
+
+	t.Run("headers inherited from parent", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errUnauthorized := m.Create("ErrUnauthorized", &ErrorConfig{
+			Status:  401,
+			Headers: map[string]string{"WWW-Authenticate": `Bearer realm="api"`},
+		})
+		errTokenExpired := m.Create("ErrTokenExpired", &ErrorConfig{Parent: errUnauthorized})
+		errInfo := m.New(errTokenExpired).Build(LanguageEn).ErrorInfo
+		assert.Equal(t, `Bearer realm="api"`, errInfo.Headers.Get("WWW-Authenticate"))
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		m := NewManager(&Config{})
+		assert.Nil(t, m.New(errTest1).Build(LanguageEn).ErrorInfo.Headers)
+	})
+
+	t.Run("multi error", func(t *testing.T) {
+		m := NewManager(&Config{})
+		me := WithHeader(m.NewMultiError(m.New(errTest1)), "Allow", "GET, POST")
+		_, ok := me.(MultiError)
+		assert.True(t, ok)
+		assert.Equal(t, "GET, POST", me.Build(LanguageEn).ErrorInfo.Headers.Get("Allow"))
+	})
+
+	t.Run("error without header support", func(t *testing.T) {
+		ae := externalAppError{New(errTest1)}
+		assert.Equal(t, ae, WithHeader(ae, "Allow", "GET, POST"))
+	})
+}
+
+// externalAppError implementation of AppError outside of the package
+type externalAppError struct {
+	AppError
+}
+
+func Test_AppError_ParamFormats(t *testing.T) {
+	translate := func(lang Language, key string, params map[string]any) (string, error) {
+		return fmt.Sprintf("%v, %v, %v, %v", params["amount"], params["since"], params["id"], params["size"]), nil
+	}
+	since := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	t.Run("formats from config and instance", func(t *testing.T) {
+		m := NewManager(&Config{TranslationFunc: translate})
+		errQuota := m.Create("ErrQuota", &ErrorConfig{
+			ParamFormats: map[string]ParamFormat{"amount": ParamFormatNumber, "size": ParamFormatBytes},
+		})
+		ae := m.New(errQuota).
+			WithParam("amount", 1234.5).
+			WithParam("since", since).
+			WithParam("id", 12345).
+			WithParam("size", 2048)
+		ae = WithParamFormat(ae, "size", ParamFormatNumber)
+		assert.Equal(t, map[string]ParamFormat{"size": ParamFormatNumber}, ae.(ParamFormatAppError).ParamFormats())
+
+		errInfo := ae.Build(LanguageDe).ErrorInfo
+		assert.Equal(t, "1.234,5, 05.03.2024 14:30, 12345, 2.048", errInfo.Message)
+
+		errInfo = WithParamFormat(ae, "since", ParamFormatRaw).Build(LanguageDe).ErrorInfo
+		assert.Equal(t, fmt.Sprintf("1.234,5, %v, 12345, 2.048", since), errInfo.Message)
+
+		errInfo = WithParamFormat(ae, "since", ParamFormatDate).Build(LanguageDe).ErrorInfo
+		assert.Equal(t, "1.234,5, 05.03.2024, 12345, 2.048", errInfo.Message)
+		// Output params are kept raw
+		assert.Equal(t, 1234.5, errInfo.Params["amount"])
+		assert.Equal(t, since, errInfo.Params["since"])
+
+		errInfo = ae.Build(LanguageEn, InfoBuilderOptionFormatParams(false)).ErrorInfo
+		assert.Equal(t, fmt.Sprintf("1234.5, %v, 12345, 2048", since), errInfo.Message)
+	})
+
+	t.Run("formats inherited from parent", func(t *testing.T) {
+		m := NewManager(&Config{TranslationFunc: translate})
+		errParent := m.Create("ErrParent", &ErrorConfig{
+			ParamFormats: map[string]ParamFormat{"size": ParamFormatBytes},
+		})
+		errChild := m.Create("ErrChild", &ErrorConfig{Parent: errParent})
+		errInfo := m.New(errChild).WithParam("size", 1536).Build(LanguageEn).ErrorInfo
+		assert.Equal(t, "<nil>, <nil>, <nil>, 1.5 KiB", errInfo.Message)
+	})
+
+	t.Run("multi error", func(t *testing.T) {
+		m := NewManager(&Config{TranslationFunc: translate})
+		me := WithParamFormat(m.NewMultiError(m.New(errTest1)).WithParam("id", 1234), "id", ParamFormatNumber)
+		_, ok := me.(MultiError)
+		assert.True(t, ok)
+		assert.Equal(t, "<nil>, <nil>, 1,234, <nil>", me.Build(LanguageEn).ErrorInfo.Message)
+	})
+
+	t.Run("error without format support", func(t *testing.T) {
+		ae := externalAppError{New(errTest1)}
+		assert.Equal(t, ae, WithParamFormat(ae, "id", ParamFormatNumber))
+	})
+}
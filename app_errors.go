@@ -8,12 +8,18 @@ import (
 // AppErrors is a defined type of slice of AppError
 type AppErrors []AppError
 
-// Error implements `error` interface
+// Error implements `error` interface.
+// Errors are separated by the separator of the global config, MultiErrors use the one of their manager.
 func (aes AppErrors) Error() string {
+	return aes.join(defaultManager.config.MultiErrorSeparator)
+}
+
+// join joins the error strings with the separator
+func (aes AppErrors) join(separator string) string {
 	var sb strings.Builder
 	for i, err := range aes {
 		if i > 0 {
-			sb.WriteString(globalConfig.MultiErrorSeparator)
+			sb.WriteString(separator)
 		}
 		sb.WriteString(err.Error())
 	}
@@ -0,0 +1,128 @@
+package goapperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCatalogFormatUnsupported is returned when a catalog format is not supported
+var ErrCatalogFormatUnsupported = errors.New("catalog format unsupported")
+
+// CatalogFormat format of an error catalog
+type CatalogFormat string
+
+const (
+	CatalogFormatJSON CatalogFormat = "json"
+	CatalogFormatYAML CatalogFormat = "yaml"
+)
+
+// Catalog describes all errors registered in a manager.
+// A catalog can be exported to share with API docs, frontend or support teams.
+type Catalog struct {
+	Errors []*CatalogEntry `json:"errors" yaml:"errors"`
+}
+
+// CatalogEntry describes an error in a catalog
+type CatalogEntry struct {
+	Code     string   `json:"code" yaml:"code"`
+	Status   int      `json:"status,omitempty" yaml:"status,omitempty"`
+	Title    string   `json:"title,omitempty" yaml:"title,omitempty"`
+	LogLevel LogLevel `json:"logLevel,omitempty" yaml:"logLevel,omitempty"`
+	TransKey string   `json:"transKey,omitempty" yaml:"transKey,omitempty"`
+	Extra    any      `json:"extra,omitempty" yaml:"extra,omitempty"`
+	GRPCCode uint32   `json:"grpcCode,omitempty" yaml:"grpcCode,omitempty"`
+	// Headers HTTP response headers with `{name}` placeholders of the error params
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// ParamFormats format hints of the params by name
+	ParamFormats map[string]ParamFormat `json:"paramFormats,omitempty" yaml:"paramFormats,omitempty"`
+
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Parent code of the parent error whose config is inherited
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Message default message with `{name}` placeholders of the error params.
+	// It is used for documentation and code generation, not when build error info.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Params Go types of the message params by name (default type is `string`).
+	// It is used for documentation and code generation.
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// Catalog returns the catalog of all registered errors sorted by code.
+// Config inheritance is resolved, and unset status and log level are filled with
+// the default values from the config.
+func (m *Manager) Catalog() *Catalog {
+	entries := m.registry.Entries()
+	catalog := &Catalog{
+		Errors: make([]*CatalogEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		cfg := m.registry.Resolve(entry.Config)
+		catalogEntry := &CatalogEntry{
+			Code:         cfg.Code,
+			Status:       cfg.Status,
+			Title:        cfg.Title,
+			LogLevel:     cfg.LogLevel,
+			TransKey:     cfg.TransKey,
+			Extra:        cfg.Extra,
+			GRPCCode:     cfg.GRPCCode,
+			Headers:      cfg.Headers,
+			ParamFormats: cfg.ParamFormats,
+
+			Description: cfg.Description,
+			Params:      cfg.Params,
+		}
+		if cfg.Parent != nil {
+			catalogEntry.Parent = cfg.Parent.Error()
+			if parentCfg := m.registry.Get(cfg.Parent); parentCfg != nil {
+				catalogEntry.Parent = parentCfg.Code
+			}
+		}
+		if catalogEntry.Status == 0 {
+			catalogEntry.Status = m.config.DefaultErrorStatus
+		}
+		if catalogEntry.LogLevel == LogLevelNone {
+			catalogEntry.LogLevel = m.config.DefaultLogLevel
+		}
+		catalog.Errors = append(catalog.Errors, catalogEntry)
+	}
+	return catalog
+}
+
+// ExportCatalog writes the catalog of all registered errors in the given format
+func (m *Manager) ExportCatalog(w io.Writer, format CatalogFormat) error {
+	catalog := m.Catalog()
+	switch format {
+	case CatalogFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(catalog); err != nil {
+			return Wrap(err)
+		}
+		return nil
+	case CatalogFormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2) //nolint:mnd
+		if err := encoder.Encode(catalog); err != nil {
+			return Wrap(err)
+		}
+		if err := encoder.Close(); err != nil {
+			return Wrap(err)
+		}
+		return nil
+	default:
+		return Wrapf("%w: %s", ErrCatalogFormatUnsupported, format)
+	}
+}
+
+// GetCatalog returns the catalog of all errors registered globally sorted by code
+func GetCatalog() *Catalog {
+	return defaultManager.Catalog()
+}
+
+// ExportCatalog writes the catalog of all errors registered globally in the given format
+func ExportCatalog(w io.Writer, format CatalogFormat) error {
+	return defaultManager.ExportCatalog(w, format)
+}
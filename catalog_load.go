@@ -0,0 +1,258 @@
+package goapperrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCatalogInvalid is returned when a catalog is malformed
+var ErrCatalogInvalid = errors.New("catalog invalid")
+
+// ParseCatalog reads and validates a catalog in the given format.
+// Unknown fields in the catalog are reported as errors to catch typos.
+func ParseCatalog(r io.Reader, format CatalogFormat) (*Catalog, error) {
+	catalog := &Catalog{}
+	switch format {
+	case CatalogFormatJSON:
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(catalog); err != nil {
+			return nil, Wrapf("%w: %w", ErrCatalogInvalid, err)
+		}
+	case CatalogFormatYAML:
+		decoder := yaml.NewDecoder(r)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(catalog); err != nil && !errors.Is(err, io.EOF) {
+			return nil, Wrapf("%w: %w", ErrCatalogInvalid, err)
+		}
+	default:
+		return nil, Wrapf("%w: %s", ErrCatalogFormatUnsupported, format)
+	}
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// ParseCatalogFile reads and validates a catalog file from the file system.
+// The format is detected from the file extension (`.json`, `.yaml` or `.yml`).
+func ParseCatalogFile(fsys fs.FS, filePath string) (*Catalog, error) {
+	format, err := catalogFormatOf(filePath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, Wrap(err)
+	}
+	return ParseCatalog(bytes.NewReader(data), format)
+}
+
+// Validate validates all entries of the catalog.
+// All found problems are reported in the returning error.
+func (c *Catalog) Validate() error {
+	var errs []error
+	codes := make(map[string]struct{}, len(c.Errors))
+	for i, entry := range c.Errors {
+		if entry == nil {
+			errs = append(errs, fmt.Errorf("%w: entry #%d is empty", ErrCatalogInvalid, i))
+			continue
+		}
+		if entry.Code == "" {
+			errs = append(errs, fmt.Errorf("%w: entry #%d has no code", ErrCatalogInvalid, i))
+		} else {
+			if _, exists := codes[entry.Code]; exists {
+				errs = append(errs, fmt.Errorf("%w: entry #%d has duplicate code %q",
+					ErrCatalogInvalid, i, entry.Code))
+			}
+			codes[entry.Code] = struct{}{}
+		}
+		if entry.Status != 0 && http.StatusText(entry.Status) == "" {
+			errs = append(errs, fmt.Errorf("%w: entry #%d (%s) has invalid status %d",
+				ErrCatalogInvalid, i, entry.Code, entry.Status))
+		}
+		switch entry.LogLevel {
+		case LogLevelNone, LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError, LogLevelFatal:
+		default:
+			errs = append(errs, fmt.Errorf("%w: entry #%d (%s) has invalid log level %q",
+				ErrCatalogInvalid, i, entry.Code, entry.LogLevel))
+		}
+		if entry.GRPCCode > maxGRPCCode {
+			errs = append(errs, fmt.Errorf("%w: entry #%d (%s) has invalid gRPC code %d",
+				ErrCatalogInvalid, i, entry.Code, entry.GRPCCode))
+		}
+		if entry.Parent != "" && entry.Parent == entry.Code {
+			errs = append(errs, fmt.Errorf("%w: entry #%d (%s) has itself as parent",
+				ErrCatalogInvalid, i, entry.Code))
+		}
+		for name, typ := range entry.Params {
+			if name == "" || typ == "" {
+				errs = append(errs, fmt.Errorf("%w: entry #%d (%s) has invalid param %q of type %q",
+					ErrCatalogInvalid, i, entry.Code, name, typ))
+			}
+		}
+	}
+	errs = append(errs, c.validateParents()...)
+	if len(errs) > 0 {
+		return Wrap(errors.Join(errs...))
+	}
+	return nil
+}
+
+// validateParents checks cyclic inheritance among the catalog entries
+func (c *Catalog) validateParents() []error {
+	parents := make(map[string]string, len(c.Errors))
+	for _, entry := range c.Errors {
+		if entry != nil && entry.Code != "" && entry.Parent != "" && entry.Parent != entry.Code {
+			parents[entry.Code] = entry.Parent
+		}
+	}
+	var errs []error
+	for i, entry := range c.Errors {
+		if entry == nil || parents[entry.Code] == "" {
+			continue
+		}
+		code := parents[entry.Code]
+		for step := 0; code != "" && step < len(parents); step++ {
+			if code == entry.Code {
+				errs = append(errs, fmt.Errorf("%w: entry #%d (%s) has cyclic inheritance",
+					ErrCatalogInvalid, i, entry.Code))
+				break
+			}
+			code = parents[code]
+		}
+	}
+	return errs
+}
+
+// LoadCatalog reads a catalog in the given format, then creates errors for all its entries.
+// This function returns the created errors by their codes. If the catalog is malformed,
+// no error is created. If some registrations are rejected (see TryCreate), the other errors
+// are still created and the failures are reported in the returning error.
+func (m *Manager) LoadCatalog(r io.Reader, format CatalogFormat) (map[string]error, error) {
+	catalog, err := ParseCatalog(r, format)
+	if err != nil {
+		return nil, err
+	}
+	return m.createCatalogErrors(catalog)
+}
+
+// LoadCatalogFile reads a catalog file from the file system (e.g. `os.DirFS` or `embed.FS`),
+// then creates errors for all its entries. See LoadCatalog for more details.
+func (m *Manager) LoadCatalogFile(fsys fs.FS, filePath string) (map[string]error, error) {
+	catalog, err := ParseCatalogFile(fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return m.createCatalogErrors(catalog)
+}
+
+// createCatalogErrors creates errors for all entries of the catalog.
+// Parent errors defined in the catalog are created before their children.
+func (m *Manager) createCatalogErrors(catalog *Catalog) (map[string]error, error) {
+	result := make(map[string]error, len(catalog.Errors))
+	pendingCodes := make(map[string]struct{}, len(catalog.Errors))
+	for _, entry := range catalog.Errors {
+		pendingCodes[entry.Code] = struct{}{}
+	}
+
+	var regErrs []error
+	pending := catalog.Errors
+	for len(pending) > 0 {
+		next := make([]*CatalogEntry, 0, len(pending))
+		for _, entry := range pending {
+			if _, parentPending := pendingCodes[entry.Parent]; parentPending {
+				next = append(next, entry)
+				continue
+			}
+			delete(pendingCodes, entry.Code)
+			err, regErr := m.createCatalogError(entry, result)
+			if regErr != nil {
+				regErrs = append(regErrs, regErr)
+				continue
+			}
+			result[entry.Code] = err
+		}
+		if len(next) == len(pending) {
+			break // Not happen as cyclic inheritance is rejected by validation
+		}
+		pending = next
+	}
+	if len(regErrs) > 0 {
+		return result, Wrap(errors.Join(regErrs...))
+	}
+	return result, nil
+}
+
+// createCatalogError creates error for the catalog entry, the parent error is looked up
+// in the created errors, then in the registry
+func (m *Manager) createCatalogError(entry *CatalogEntry, created map[string]error) (error, error) {
+	cfg := entry.ErrorConfig()
+	if entry.Parent != "" {
+		cfg.Parent = created[entry.Parent]
+		if cfg.Parent == nil {
+			cfg.Parent = m.registry.GetByCode(entry.Parent)
+		}
+		if cfg.Parent == nil {
+			return nil, fmt.Errorf("%w: parent %q of %q not found", ErrCatalogInvalid, entry.Parent, entry.Code)
+		}
+	}
+	return m.TryCreate(entry.Code, cfg)
+}
+
+// ErrorConfig creates a new ErrorConfig from the catalog entry
+func (entry *CatalogEntry) ErrorConfig() *ErrorConfig {
+	return &ErrorConfig{
+		Status:       entry.Status,
+		Code:         entry.Code,
+		Title:        entry.Title,
+		LogLevel:     entry.LogLevel,
+		TransKey:     entry.TransKey,
+		Extra:        entry.Extra,
+		GRPCCode:     entry.GRPCCode,
+		Headers:      entry.Headers,
+		ParamFormats: entry.ParamFormats,
+		Description:  entry.Description,
+		Params:       entry.Params,
+	}
+}
+
+// LoadCatalog reads a catalog in the given format, then creates errors for all its entries
+// in the global config mappings. See Manager.LoadCatalog for more details.
+func LoadCatalog(r io.Reader, format CatalogFormat) (map[string]error, error) {
+	return defaultManager.LoadCatalog(r, format)
+}
+
+// LoadCatalogFile reads a catalog file from the file system, then creates errors for all its
+// entries in the global config mappings. See Manager.LoadCatalog for more details.
+//
+// Example:
+//
+//	//go:embed errors.yaml
+//	var catalogFS embed.FS
+//
+//	errs, err := LoadCatalogFile(catalogFS, "errors.yaml")
+func LoadCatalogFile(fsys fs.FS, filePath string) (map[string]error, error) {
+	return defaultManager.LoadCatalogFile(fsys, filePath)
+}
+
+// catalogFormatOf detects catalog format from the file extension
+func catalogFormatOf(filePath string) (CatalogFormat, error) {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".json":
+		return CatalogFormatJSON, nil
+	case ".yaml", ".yml":
+		return CatalogFormatYAML, nil
+	default:
+		return "", Wrapf("%w: %s", ErrCatalogFormatUnsupported, filePath)
+	}
+}
@@ -0,0 +1,176 @@
+package goapperrors
+
+import (
+	"embed"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/catalog.yaml testdata/catalog.json
+var testCatalogFS embed.FS
+
+func Test_ParseCatalog(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		catalog, err := ParseCatalog(strings.NewReader(`
+errors:
+  - code: ErrA
+    status: 400
+  - code: ErrB
+`), CatalogFormatYAML)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(catalog.Errors))
+		assert.Equal(t, "ErrA", catalog.Errors[0].Code)
+		assert.Equal(t, 400, catalog.Errors[0].Status)
+	})
+
+	t.Run("empty yaml", func(t *testing.T) {
+		catalog, err := ParseCatalog(strings.NewReader(""), CatalogFormatYAML)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(catalog.Errors))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader(`{"errors": [{"code": "ErrA", "stauts": 400}]}`), CatalogFormatJSON)
+		assert.ErrorIs(t, err, ErrCatalogInvalid)
+		_, err = ParseCatalog(strings.NewReader("errors:\n  - code: ErrA\n    stauts: 400\n"), CatalogFormatYAML)
+		assert.ErrorIs(t, err, ErrCatalogInvalid)
+	})
+
+	t.Run("malformed entries", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader(`{"errors": [
+			{"status": 400},
+			{"code": "ErrA", "status": 1000},
+			{"code": "ErrA", "logLevel": "critical"},
+			null,
+			{"code": "ErrB", "params": {"count": ""}},
+			{"code": "ErrC", "grpcCode": 17}
+		]}`), CatalogFormatJSON)
+		assert.ErrorIs(t, err, ErrCatalogInvalid)
+		assert.Contains(t, err.Error(), "entry #0 has no code")
+		assert.Contains(t, err.Error(), "entry #1 (ErrA) has invalid status 1000")
+		assert.Contains(t, err.Error(), `entry #2 has duplicate code "ErrA"`)
+		assert.Contains(t, err.Error(), `entry #2 (ErrA) has invalid log level "critical"`)
+		assert.Contains(t, err.Error(), "entry #3 is empty")
+		assert.Contains(t, err.Error(), `entry #4 (ErrB) has invalid param "count" of type ""`)
+		assert.Contains(t, err.Error(), "entry #5 (ErrC) has invalid gRPC code 17")
+	})
+
+	t.Run("invalid inheritance", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader(`{"errors": [
+			{"code": "ErrA", "parent": "ErrA"},
+			{"code": "ErrB", "parent": "ErrC"},
+			{"code": "ErrC", "parent": "ErrB"},
+			{"code": "ErrD", "parent": "ErrB"}
+		]}`), CatalogFormatJSON)
+		assert.ErrorIs(t, err, ErrCatalogInvalid)
+		assert.Contains(t, err.Error(), "entry #0 (ErrA) has itself as parent")
+		assert.Contains(t, err.Error(), "entry #1 (ErrB) has cyclic inheritance")
+		assert.Contains(t, err.Error(), "entry #2 (ErrC) has cyclic inheritance")
+		assert.NotContains(t, err.Error(), "ErrD")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader(""), "toml")
+		assert.ErrorIs(t, err, ErrCatalogFormatUnsupported)
+		_, err = ParseCatalogFile(fstest.MapFS{}, "errors.toml")
+		assert.ErrorIs(t, err, ErrCatalogFormatUnsupported)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := ParseCatalogFile(fstest.MapFS{}, "errors.yaml")
+		assert.Error(t, err)
+	})
+}
+
+func Test_Manager_LoadCatalogFile(t *testing.T) {
+	for _, file := range []string{"testdata/catalog.yaml", "testdata/catalog.json"} {
+		t.Run(file, func(t *testing.T) {
+			m := NewManager(&Config{TranslationFunc: testTranslateOK})
+			errs, err := m.LoadCatalogFile(testCatalogFS, file)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(errs))
+
+			errUserNotFound := errs["ErrUserNotFound"]
+			assert.Equal(t, "ErrUserNotFound", errUserNotFound.Error())
+			res := m.Build(m.New(errUserNotFound), LanguageEn)
+			assert.Equal(t, 404, res.ErrorInfo.Status)
+			assert.Equal(t, "(user.notFound)-in-en", res.ErrorInfo.Message)
+			assert.Equal(t, "(User not found)-in-en", res.ErrorInfo.Title)
+
+			errCfg := m.GetErrorConfig(errs["ErrQuotaExceeded"])
+			assert.Equal(t, 429, errCfg.Status)
+			assert.Equal(t, LogLevelWarn, errCfg.LogLevel)
+			assert.Equal(t, map[string]any{"retryable": true}, errCfg.Extra)
+			assert.Equal(t, map[string]string{"Retry-After": "60"}, errCfg.Headers)
+			assert.Equal(t, map[string]ParamFormat{"limit": ParamFormatNumber}, errCfg.ParamFormats)
+		})
+	}
+
+	t.Run("malformed catalog creates no error", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errs, err := m.LoadCatalog(strings.NewReader(`{"errors": [{"code": "ErrA"}, {"status": 400}]}`),
+			CatalogFormatJSON)
+		assert.ErrorIs(t, err, ErrCatalogInvalid)
+		assert.Nil(t, errs)
+		assert.Equal(t, 0, len(m.Entries()))
+	})
+
+	t.Run("inheritance", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errBase := m.Create("ErrBase", &ErrorConfig{Status: 404, LogLevel: LogLevelInfo})
+		errs, err := m.LoadCatalog(strings.NewReader(`
+errors:
+  - code: ErrLeaf
+    parent: ErrMid
+  - code: ErrMid
+    parent: ErrBase
+    logLevel: warning
+  - code: ErrOrphan
+    parent: ErrUnknown
+`), CatalogFormatYAML)
+		assert.ErrorIs(t, err, ErrCatalogInvalid)
+		assert.Contains(t, err.Error(), `parent "ErrUnknown" of "ErrOrphan" not found`)
+		assert.Equal(t, 2, len(errs))
+		assert.ErrorIs(t, errs["ErrLeaf"], errs["ErrMid"])
+		assert.ErrorIs(t, errs["ErrLeaf"], errBase)
+
+		res := m.Build(errs["ErrLeaf"], LanguageEn)
+		assert.Equal(t, 404, res.ErrorInfo.Status)
+		assert.Equal(t, LogLevelWarn, res.ErrorInfo.LogLevel)
+
+		var leaf *CatalogEntry
+		for _, entry := range m.Catalog().Errors {
+			if entry.Code == "ErrLeaf" {
+				leaf = entry
+			}
+		}
+		assert.Equal(t, &CatalogEntry{Code: "ErrLeaf", Status: 404, LogLevel: LogLevelWarn,
+			TransKey: "ErrLeaf", Parent: "ErrMid"}, leaf)
+	})
+
+	t.Run("rejected registrations", func(t *testing.T) {
+		m := NewManager(&Config{DuplicateHandling: DuplicateError})
+		_ = m.Create("ErrQuotaExceeded", &ErrorConfig{})
+		errs, err := m.LoadCatalogFile(testCatalogFS, "testdata/catalog.yaml")
+		assert.ErrorIs(t, err, ErrDuplicateCode)
+		assert.Equal(t, 1, len(errs))
+		assert.NotNil(t, errs["ErrUserNotFound"])
+	})
+}
+
+func Test_LoadCatalog(t *testing.T) {
+	errs, err := LoadCatalog(strings.NewReader(`{"errors": [{"code": "ErrGlobalCatalog", "status": 409}]}`),
+		CatalogFormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, 409, GetErrorConfig(errs["ErrGlobalCatalog"]).Status)
+	assert.NoError(t, TryRemove(errs["ErrGlobalCatalog"]))
+
+	errs, err = LoadCatalogFile(testCatalogFS, "testdata/catalog.json")
+	assert.NoError(t, err)
+	for _, e := range errs {
+		assert.NoError(t, TryRemove(e))
+	}
+}
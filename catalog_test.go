@@ -0,0 +1,66 @@
+package goapperrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func newTestCatalogManager() *Manager {
+	m := NewManager(&Config{DefaultLogLevel: LogLevelInfo})
+	_ = m.Create("ErrNotFound", &ErrorConfig{Status: 404, Title: "Not found", Extra: map[string]any{"k": "v"}})
+	_ = m.Create("ErrAuth", &ErrorConfig{Status: 401, LogLevel: LogLevelWarn, TransKey: "auth.failed"})
+	_ = m.Add(errTest1, &ErrorConfig{})
+	return m
+}
+
+func Test_Manager_Entries(t *testing.T) {
+	m := newTestCatalogManager()
+	entries := m.Entries()
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "ErrAuth", entries[0].Config.Code)
+	assert.Equal(t, "ErrNotFound", entries[1].Config.Code)
+	assert.Equal(t, "ErrTest1", entries[2].Config.Code)
+	assert.Equal(t, errTest1, entries[2].Error)
+}
+
+func Test_Manager_Catalog(t *testing.T) {
+	m := newTestCatalogManager()
+	catalog := m.Catalog()
+	assert.Equal(t, []*CatalogEntry{
+		{Code: "ErrAuth", Status: 401, LogLevel: LogLevelWarn, TransKey: "auth.failed"},
+		{Code: "ErrNotFound", Status: 404, Title: "Not found", LogLevel: LogLevelInfo, TransKey: "ErrNotFound",
+			Extra: map[string]any{"k": "v"}},
+		{Code: "ErrTest1", Status: 500, LogLevel: LogLevelInfo, TransKey: "ErrTest1"},
+	}, catalog.Errors)
+}
+
+func Test_Manager_ExportCatalog(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		m := newTestCatalogManager()
+		var buf bytes.Buffer
+		assert.NoError(t, m.ExportCatalog(&buf, CatalogFormatJSON))
+
+		var catalog Catalog
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &catalog))
+		assert.Equal(t, m.Catalog(), &catalog)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		m := newTestCatalogManager()
+		var buf bytes.Buffer
+		assert.NoError(t, m.ExportCatalog(&buf, CatalogFormatYAML))
+
+		var catalog Catalog
+		assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &catalog))
+		assert.Equal(t, m.Catalog(), &catalog)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		m := newTestCatalogManager()
+		assert.ErrorIs(t, m.ExportCatalog(&bytes.Buffer{}, "xml"), ErrCatalogFormatUnsupported)
+	})
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/fs"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+var (
+	errInputRequired  = errors.New("input catalog file is required")
+	errPackageMissing = errors.New("package name is required")
+	errInvalidName    = errors.New("invalid name")
+	errInvalidExtra   = errors.New("invalid extra")
+	errUnknownParent  = errors.New("unknown parent")
+)
+
+// Options options for generating code
+type Options struct {
+	// Package package name of the generated file
+	Package string
+	// Manager variable name of a goapperrors.Manager to use instead of the package functions
+	Manager string
+	// Source catalog file path to be mentioned in the generated file header
+	Source string
+	// ExternalParents allows parent codes not defined in the catalog, their Go names must be
+	// declared in other files of the package
+	ExternalParents bool
+}
+
+type genParam struct {
+	Key  string
+	Arg  string
+	Type string
+}
+
+type genError struct {
+	Name         string
+	Comment      []string
+	Message      []string
+	Code         string
+	Status       int
+	GRPCCode     uint32
+	Title        string
+	LogLevel     string
+	TransKey     string
+	Description  string
+	Extra        string
+	Headers      string
+	ParamFormats string
+	Parent       string
+	Params       []*genParam
+}
+
+type genData struct {
+	Package string
+	Source  string
+	Creator string
+	Errors  []*genError
+}
+
+// Generate generates Go code for errors defined in the catalog file
+func Generate(fsys fs.FS, filePath string, opts *Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, errPackageMissing
+	}
+	if opts.Manager != "" && !token.IsIdentifier(opts.Manager) {
+		return nil, fmt.Errorf("%w: manager %q", errInvalidName, opts.Manager)
+	}
+	catalog, err := goapperrors.ParseCatalogFile(fsys, filePath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	data := &genData{
+		Package: opts.Package,
+		Source:  opts.Source,
+		Creator: "goapperrors",
+	}
+	if data.Source == "" {
+		data.Source = filePath
+	}
+	if opts.Manager != "" {
+		data.Creator = opts.Manager
+	}
+	codes := make(map[string]struct{}, len(catalog.Errors))
+	for _, entry := range catalog.Errors {
+		codes[entry.Code] = struct{}{}
+	}
+	names := make(map[string]string, len(catalog.Errors))
+	for _, entry := range catalog.Errors {
+		if _, exists := codes[entry.Parent]; entry.Parent != "" && !exists && !opts.ExternalParents {
+			return nil, fmt.Errorf("%w: parent %q of code %q is not in the catalog", errUnknownParent,
+				entry.Parent, entry.Code)
+		}
+		genErr, err := newGenError(entry)
+		if err != nil {
+			return nil, err
+		}
+		if code, exists := names[genErr.Name]; exists {
+			return nil, fmt.Errorf("%w: codes %q and %q have the same Go name %s",
+				errInvalidName, code, entry.Code, genErr.Name)
+		}
+		names[genErr.Name] = entry.Code
+		data.Errors = append(data.Errors, genErr)
+	}
+
+	var buf bytes.Buffer
+	if err = codeTemplate.Execute(&buf, data); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return code, nil
+}
+
+func newGenError(entry *goapperrors.CatalogEntry) (*genError, error) {
+	name := goName(entry.Code)
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("%w: code %q can't be converted to a Go name", errInvalidName, entry.Code)
+	}
+	genErr := &genError{
+		Name:        name,
+		Code:        entry.Code,
+		Status:      entry.Status,
+		GRPCCode:    entry.GRPCCode,
+		Title:       entry.Title,
+		LogLevel:    logLevelExpr(entry.LogLevel),
+		TransKey:    entry.TransKey,
+		Description: entry.Description,
+	}
+	if entry.Description != "" {
+		genErr.Comment = strings.Split(strings.TrimSpace(entry.Description), "\n")
+		genErr.Comment[0] = name + " " + genErr.Comment[0]
+	}
+	if entry.Message != "" {
+		genErr.Message = strings.Split(strings.TrimSpace(entry.Message), "\n")
+		genErr.Message[0] = "Message: " + genErr.Message[0]
+	}
+	if entry.Extra != nil {
+		extra, err := extraLiteral(entry.Extra)
+		if err != nil {
+			return nil, fmt.Errorf("code %q: %w", entry.Code, err)
+		}
+		genErr.Extra = extra
+	}
+	if len(entry.Headers) > 0 {
+		genErr.Headers = fmt.Sprintf("%#v", entry.Headers)
+	}
+	if len(entry.ParamFormats) > 0 {
+		genErr.ParamFormats = fmt.Sprintf("%#v", entry.ParamFormats)
+	}
+	if entry.Parent != "" {
+		genErr.Parent = goName(entry.Parent)
+	}
+
+	// Params come in the order of the message placeholders, then the remaining declared ones
+	keys := messagePlaceholders(entry.Message)
+	declaredKeys := make([]string, 0, len(entry.Params))
+	for key := range entry.Params {
+		declaredKeys = append(declaredKeys, key)
+	}
+	sort.Strings(declaredKeys)
+	keys = appendUnique(keys, declaredKeys...)
+
+	args := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		arg := argName(key)
+		if !token.IsIdentifier(arg) {
+			return nil, fmt.Errorf("%w: param %q of code %q", errInvalidName, key, entry.Code)
+		}
+		if _, exists := args[arg]; exists {
+			return nil, fmt.Errorf("%w: params of code %q have the same argument name %s",
+				errInvalidName, entry.Code, arg)
+		}
+		args[arg] = struct{}{}
+		typ := entry.Params[key]
+		if typ == "" {
+			typ = "string"
+		}
+		genErr.Params = append(genErr.Params, &genParam{Key: key, Arg: arg, Type: typ})
+	}
+	return genErr, nil
+}
+
+// extraLiteral converts an extra value to a Go literal. Only JSON-compatible values are supported:
+// nil, booleans, numbers, strings, maps with string keys and slices of them.
+func extraLiteral(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case string:
+		return strconv.Quote(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return "int64(" + strconv.FormatInt(val, 10) + ")", nil
+	case uint64:
+		return "uint64(" + strconv.FormatUint(val, 10) + ")", nil
+	case float64:
+		if math.IsInf(val, 0) || math.IsNaN(val) {
+			return "", fmt.Errorf("%w: unsupported number %v", errInvalidExtra, val)
+		}
+		return "float64(" + strconv.FormatFloat(val, 'g', -1, 64) + ")", nil
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]string, 0, len(keys))
+		for _, k := range keys {
+			item, err := extraLiteral(val[k])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, strconv.Quote(k)+": "+item)
+		}
+		return "map[string]any{" + strings.Join(items, ", ") + "}", nil
+	case []any:
+		items := make([]string, 0, len(val))
+		for _, v := range val {
+			item, err := extraLiteral(v)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[]any{" + strings.Join(items, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("%w: unsupported value of type %T", errInvalidExtra, v)
+	}
+}
+
+// goName converts an error code to an exported Go name, e.g. `user.not_found` to `ErrUserNotFound`
+func goName(code string) string {
+	var sb strings.Builder
+	upperNext := true
+	for _, r := range code {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		sb.WriteRune(r)
+	}
+	name := sb.String()
+	if !strings.HasPrefix(name, "Err") {
+		name = "Err" + name
+	}
+	return name
+}
+
+// argName converts a param key to a Go function argument name
+func argName(key string) string {
+	if key == "" {
+		return ""
+	}
+	runes := []rune(key)
+	runes[0] = unicode.ToLower(runes[0])
+	arg := string(runes)
+	if token.IsKeyword(arg) {
+		arg += "Param"
+	}
+	return arg
+}
+
+func logLevelExpr(logLevel goapperrors.LogLevel) string {
+	switch logLevel {
+	case goapperrors.LogLevelNone:
+		return ""
+	case goapperrors.LogLevelDebug:
+		return "LogLevelDebug"
+	case goapperrors.LogLevelInfo:
+		return "LogLevelInfo"
+	case goapperrors.LogLevelWarn:
+		return "LogLevelWarn"
+	case goapperrors.LogLevelError:
+		return "LogLevelError"
+	case goapperrors.LogLevelFatal:
+		return "LogLevelFatal"
+	}
+	return ""
+}
+
+// messagePlaceholders returns names of the placeholders in the message in order of appearance.
+// Both simple placeholders like `{name}` and complex arguments like `{count, plural, ...}`
+// are supported, placeholders nested in the branches of complex arguments are also returned.
+func messagePlaceholders(msg string) []string {
+	var names []string
+	for i := 0; i < len(msg); i++ {
+		if msg[i] != '{' {
+			continue
+		}
+		end := matchingBrace(msg, i)
+		if end < 0 {
+			break
+		}
+		parts := strings.SplitN(msg[i+1:end], ",", 3) //nolint:mnd
+		names = appendUnique(names, strings.TrimSpace(parts[0]))
+		if len(parts) == 3 { //nolint:mnd
+			names = appendUnique(names, branchPlaceholders(parts[2])...)
+		}
+		i = end
+	}
+	return names
+}
+
+// branchPlaceholders returns names of the placeholders in the branches of a complex argument,
+// e.g. `one {# item of {name}} other {# items of {name}}`
+func branchPlaceholders(branches string) []string {
+	var names []string
+	for i := 0; i < len(branches); i++ {
+		if branches[i] != '{' {
+			continue
+		}
+		end := matchingBrace(branches, i)
+		if end < 0 {
+			break
+		}
+		names = appendUnique(names, messagePlaceholders(branches[i+1:end])...)
+		i = end
+	}
+	return names
+}
+
+// matchingBrace returns index of the brace closing the one at the given position, or -1
+func matchingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		exists := false
+		for _, v := range list {
+			if v == item {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+var codeTemplate = template.Must(template.New("code").Parse(`// Code generated by apperrors-gen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+var (
+{{- range .Errors}}
+	{{- range .Comment}}
+	// {{.}}
+	{{- end}}
+	{{.Name}} = {{$.Creator}}.Create({{printf "%q" .Code}}, &goapperrors.ErrorConfig{
+		{{- if .Status}}
+		Status: {{.Status}},
+		{{- end}}
+		{{- if .Title}}
+		Title: {{printf "%q" .Title}},
+		{{- end}}
+		{{- if .LogLevel}}
+		LogLevel: goapperrors.{{.LogLevel}},
+		{{- end}}
+		{{- if .TransKey}}
+		TransKey: {{printf "%q" .TransKey}},
+		{{- end}}
+		{{- if .Extra}}
+		Extra: {{.Extra}},
+		{{- end}}
+		{{- if .Description}}
+		Description: {{printf "%q" .Description}},
+		{{- end}}
+		{{- if .Parent}}
+		Parent: {{.Parent}},
+		{{- end}}
+		{{- if .GRPCCode}}
+		GRPCCode: {{.GRPCCode}},
+		{{- end}}
+		{{- if .Headers}}
+		Headers: {{.Headers}},
+		{{- end}}
+		{{- if .ParamFormats}}
+		ParamFormats: {{.ParamFormats}},
+		{{- end}}
+	})
+{{- end}}
+)
+{{range .Errors}}
+// New{{.Name}} creates an AppError of {{.Name}}
+{{- if .Message}}
+//
+{{- range .Message}}
+// {{.}}
+{{- end}}
+{{- end}}
+func New{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Arg}} {{$p.Type}}{{end}}) goapperrors.AppError {
+	return {{$.Creator}}.New({{.Name}}){{range .Params}}.
+		WithParam({{printf "%q" .Key}}, {{.Arg}}){{end}}
+}
+{{end}}`))
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		code, err := Generate(os.DirFS("testdata"), "errors.yaml", &Options{Package: "apperrors"})
+		assert.NoError(t, err)
+		expected, err := os.ReadFile("testdata/errors_gen.go.golden")
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(code))
+	})
+
+	t.Run("with manager", func(t *testing.T) {
+		code, err := Generate(os.DirFS("testdata"), "errors.yaml", &Options{Package: "apperrors", Manager: "Errs"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(code), `Errs.Create("ErrInternal", &goapperrors.ErrorConfig{})`)
+		assert.Contains(t, string(code), `return Errs.New(ErrInternal)`)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := Generate(os.DirFS("testdata"), "errors.yaml", &Options{})
+		assert.ErrorIs(t, err, errPackageMissing)
+		_, err = Generate(os.DirFS("testdata"), "errors.yaml", &Options{Package: "p", Manager: "a.b"})
+		assert.ErrorIs(t, err, errInvalidName)
+	})
+
+	t.Run("invalid catalog", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"empty.json":      {Data: []byte(`{"errors": [{"status": 404}]}`)},
+			"same_name.json":  {Data: []byte(`{"errors": [{"code": "user.notFound"}, {"code": "ErrUserNotFound"}]}`)},
+			"bad_param.json":  {Data: []byte(`{"errors": [{"code": "ErrA", "message": "{user-id}"}]}`)},
+			"same_arg.json":   {Data: []byte(`{"errors": [{"code": "ErrA", "message": "{ID} {iD}"}]}`)},
+			"bad_code.json":   {Data: []byte(`{"errors": [{"code": "-"}]}`)},
+			"bad_parent.json": {Data: []byte(`{"errors": [{"code": "ErrA", "parent": "ErrNotFound"}]}`)},
+			"bad_extra.yaml":  {Data: []byte("errors:\n  - code: ErrA\n    extra:\n      since: 2024-03-05\n")},
+		}
+		_, err := Generate(fsys, "empty.json", &Options{Package: "p"})
+		assert.Error(t, err)
+		_, err = Generate(fsys, "same_name.json", &Options{Package: "p"})
+		assert.ErrorIs(t, err, errInvalidName)
+		_, err = Generate(fsys, "bad_param.json", &Options{Package: "p"})
+		assert.ErrorIs(t, err, errInvalidName)
+		_, err = Generate(fsys, "same_arg.json", &Options{Package: "p"})
+		assert.ErrorIs(t, err, errInvalidName)
+		_, err = Generate(fsys, "bad_code.json", &Options{Package: "p"})
+		assert.NoError(t, err) // converted to `Err`
+		_, err = Generate(fsys, "bad_extra.yaml", &Options{Package: "p"})
+		assert.ErrorIs(t, err, errInvalidExtra)
+		_, err = Generate(fsys, "bad_parent.json", &Options{Package: "p"})
+		assert.ErrorIs(t, err, errUnknownParent)
+		code, err := Generate(fsys, "bad_parent.json", &Options{Package: "p", ExternalParents: true})
+		assert.NoError(t, err)
+		assert.Contains(t, string(code), "Parent: ErrNotFound,")
+	})
+}
+
+func Test_Run(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "apperrors", "errors_gen.go")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(out), 0o755))
+	assert.NoError(t, run([]string{"-in", "testdata/errors.yaml", "-out", out}))
+	code, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(code), "package apperrors")
+
+	assert.ErrorIs(t, run([]string{}), errInputRequired)
+	assert.Error(t, run([]string{"-unknown"}))
+}
+
+func Test_messagePlaceholders(t *testing.T) {
+	assert.Nil(t, messagePlaceholders("no placeholders"))
+	assert.Equal(t, []string{"a", "b"}, messagePlaceholders("{a} and {b} and {a}"))
+	assert.Equal(t, []string{"count", "name"},
+		messagePlaceholders("{count, plural, one {# item of {name}} other {# items of {name}}}"))
+	assert.Equal(t, []string{"a"}, messagePlaceholders("{a} {unclosed"))
+}
+
+func Test_extraLiteral(t *testing.T) {
+	s, err := extraLiteral(map[string]any{
+		"b": true, "s": "x\"y", "n": nil, "i": 1, "f": 2.0, "l": []any{int64(1), uint64(2), 1.5},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `map[string]any{"b": true, "f": float64(2), "i": 1, `+
+		`"l": []any{int64(1), uint64(2), float64(1.5)}, "n": nil, "s": "x\"y"}`, s)
+
+	for _, v := range []any{time.Now(), map[any]any{1: "x"}, []string{"x"}, math.Inf(1), []any{struct{}{}}} {
+		_, err = extraLiteral(v)
+		assert.ErrorIs(t, err, errInvalidExtra, v)
+	}
+}
+
+func Test_goName(t *testing.T) {
+	assert.Equal(t, "ErrUserNotFound", goName("ErrUserNotFound"))
+	assert.Equal(t, "ErrUserNotFound", goName("user.not_found"))
+	assert.Equal(t, "ErrUserNotFound", goName("user-notFound"))
+}
@@ -0,0 +1,81 @@
+// Command apperrors-gen generates Go code for errors defined in a catalog file.
+//
+// For every catalog entry, an exported error variable is created via `goapperrors.Create`,
+// together with a typed constructor function whose parameters match the placeholders
+// in the entry message.
+//
+// Usage:
+//
+//	//go:generate go run github.com/tiendc/go-apperrors/cmd/apperrors-gen -in errors.yaml -out errors_gen.go
+//
+// Flags:
+//
+//	-in                catalog file (.yaml, .yml or .json)
+//	-out               output Go file (default: stdout)
+//	-pkg               package name of the output file (default: $GOPACKAGE or the output directory name)
+//	-manager           variable name of a goapperrors.Manager to use instead of the package functions
+//	-external-parents  allow parent codes not defined in the catalog, their Go names must be declared
+//	                   in other files of the package
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "apperrors-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("apperrors-gen", flag.ContinueOnError)
+	in := flags.String("in", "", "catalog file (.yaml, .yml or .json)")
+	out := flags.String("out", "", "output Go file (default: stdout)")
+	pkg := flags.String("pkg", "", "package name of the output file")
+	manager := flags.String("manager", "", "variable name of a goapperrors.Manager to use")
+	externalParents := flags.Bool("external-parents", false, "allow parent codes not defined in the catalog")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if *in == "" {
+		return errInputRequired
+	}
+
+	pkgName := *pkg
+	if pkgName == "" {
+		pkgName = os.Getenv("GOPACKAGE")
+	}
+	if pkgName == "" && *out != "" {
+		absOut, err := filepath.Abs(*out)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		pkgName = filepath.Base(filepath.Dir(absOut))
+	}
+
+	dir, file := filepath.Split(*in)
+	if dir == "" {
+		dir = "."
+	}
+	code, err := Generate(os.DirFS(dir), file, &Options{
+		Package: pkgName,
+		Manager: *manager,
+		Source:  filepath.ToSlash(*in),
+
+		ExternalParents: *externalParents,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(code)
+		return err //nolint:wrapcheck
+	}
+	return os.WriteFile(*out, code, 0o644) //nolint:gosec,mnd,wrapcheck
+}
@@ -18,7 +18,8 @@ type Config struct {
 	// If WrapFunc is set with custom value, this config has no effect.
 	MaxStackDepth int
 
-	// DefaultLanguage default language (default: `LanguageEn`)
+	// DefaultLanguage default language, it is also the last fallback language when a translation
+	// is missing (default: `LanguageEn`)
 	DefaultLanguage Language
 	// TranslationFunc function to translate message into a specific language (default: `nil`)
 	TranslationFunc TranslationFunc
@@ -38,6 +39,13 @@ type Config struct {
 
 	// DefaultLogLevel default log level for errors if unset (default: `LogLevelNone`)
 	DefaultLogLevel LogLevel
+
+	// DuplicateHandling action on duplicate registrations of errors, codes or translation keys
+	// (default: `DuplicateAllow`)
+	DuplicateHandling DuplicateHandling
+	// WarningFunc function to report warnings such as duplicate registrations (default: `nil`).
+	// If `nil`, warnings are written via the standard logger.
+	WarningFunc func(error)
 }
 
 func (cfg *Config) setDefault() {
@@ -74,8 +82,14 @@ const (
 	defaultLogLevel              = LogLevelNone
 )
 
-var (
-	globalConfig = &Config{
+// defaultManager backs the package-level functions
+var defaultManager = &Manager{
+	config:   defaultConfig(),
+	registry: NewRegistry(),
+}
+
+func defaultConfig() *Config {
+	return &Config{
 		Debug:         false,
 		MaxStackDepth: defaultMaxStackDepth,
 
@@ -89,6 +103,4 @@ var (
 
 		DefaultLogLevel: defaultLogLevel,
 	}
-
-	mapError = make(map[error]*ErrorConfig, 50) //nolint:mnd
-)
+}
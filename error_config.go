@@ -24,28 +24,100 @@ type ErrorConfig struct {
 	LogLevel LogLevel
 	TransKey string
 	Extra    any
+	// Description describes the error for documentation purpose, it is not used when build error info
+	Description string
+	// Params Go types of the error params by name for documentation purpose
+	Params map[string]string
+	// Parent parent error whose config is inherited.
+	// Unset `Status`, `GRPCCode`, `LogLevel`, `Title`, `Extra`, `Headers` and `ParamFormats` are
+	// taken from the parent config when build error info.
+	Parent error
+	// GRPCCode gRPC status code (see google.golang.org/grpc/codes) used when the error is returned
+	// from a gRPC service. If unset, the code is derived from `Status`.
+	GRPCCode uint32
+	// Headers HTTP response headers to be set when the error is written to a response,
+	// e.g. `Retry-After` or `WWW-Authenticate`. Values can have `{name}` placeholders
+	// which are replaced by the error params.
+	Headers map[string]string
+	// ParamFormats format hints of the params by name, used to localize the param values
+	// for the build language, e.g. `{"amount": "currency:EUR", "size": "bytes"}`
+	ParamFormats map[string]ParamFormat
 }
 
-// GetErrorConfig gets global mapping config of an error if set
-func GetErrorConfig(err error) *ErrorConfig {
-	if err == nil {
-		return nil
+// maxGRPCCode max value of gRPC status codes
+const maxGRPCCode = 16
+
+// maxInheritanceDepth max depth of config inheritance to avoid infinite loop
+const maxInheritanceDepth = 20
+
+// childError error created with a parent error.
+// errors.Is() returns `true` when compare this error with its parent.
+type childError struct {
+	code   string
+	parent error
+}
+
+// Error implements `error` interface
+func (e *childError) Error() string {
+	return e.code
+}
+
+// Is implementation used by errors.Is()
+func (e *childError) Is(err error) bool {
+	return errors.Is(e.parent, err)
+}
+
+// HTTPStatusProvider can be implemented by errors to provide their HTTP status
+type HTTPStatusProvider interface {
+	HTTPStatus() int
+}
+
+// ErrorCodeProvider can be implemented by errors to provide their code
+type ErrorCodeProvider interface {
+	ErrorCode() string
+}
+
+// LogLevelProvider can be implemented by errors to provide their log level
+type LogLevelProvider interface {
+	LogLevel() LogLevel
+}
+
+// TranslationKeyProvider can be implemented by errors to provide their translation key
+type TranslationKeyProvider interface {
+	TranslationKey() string
+}
+
+// discoverErrorConfig discovers config of an error via the provider interfaces implemented
+// by the errors in the chain. Each field is taken from the first error implementing the
+// corresponding interface. Returns `nil` if no interface is implemented.
+func discoverErrorConfig(err error) *ErrorConfig {
+	var (
+		cfg              = &ErrorConfig{}
+		found            bool
+		statusProvider   HTTPStatusProvider
+		codeProvider     ErrorCodeProvider
+		logLevelProvider LogLevelProvider
+		transKeyProvider TranslationKeyProvider
+	)
+	if errors.As(err, &statusProvider) {
+		cfg.Status, found = statusProvider.HTTPStatus(), true
+	}
+	if errors.As(err, &codeProvider) {
+		cfg.Code, found = codeProvider.ErrorCode(), true
 	}
-	if cfg := getValueInErrorMap(err); cfg != nil {
-		return cfg
+	if errors.As(err, &logLevelProvider) {
+		cfg.LogLevel, found = logLevelProvider.LogLevel(), true
 	}
-	return GetErrorConfig(errors.Unwrap(err))
-}
-
-// getValueInErrorMap returns the value for the error key in the map.
-// If the error key is unhashable, getting value from a map will panic.
-// In that situation this func will recover from panic and return `zero` value.
-func getValueInErrorMap(err error) *ErrorConfig {
-	defer func() {
-		_ = recover()
-	}()
-	if cfg, ok := mapError[err]; ok {
-		return cfg
+	if errors.As(err, &transKeyProvider) {
+		cfg.TransKey, found = transKeyProvider.TranslationKey(), true
 	}
-	return nil
+	if !found {
+		return nil
+	}
+	return cfg
+}
+
+// GetErrorConfig gets global mapping config of an error if set
+func GetErrorConfig(err error) *ErrorConfig {
+	return defaultManager.GetErrorConfig(err)
 }
@@ -52,3 +52,124 @@ func Test_GetErrorConfig(t *testing.T) {
 		assert.Nil(t, GetErrorConfig(errors.Join(errTest1, errTest2)))
 	})
 }
+
+func Test_ErrorConfig_Inheritance(t *testing.T) {
+	t.Run("child inherits unset fields", func(t *testing.T) {
+		m := NewManager(&Config{TranslationFunc: testTranslateOK})
+		errBase := m.Create("ErrNotFound", &ErrorConfig{
+			Status:   404,
+			Title:    "Not found",
+			LogLevel: LogLevelInfo,
+			Extra:    "extra",
+			GRPCCode: 5,
+		})
+		errMid := m.CreateChild(errBase, "ErrResourceNotFound", &ErrorConfig{LogLevel: LogLevelWarn})
+		errLeaf := m.CreateChild(errMid, "ErrProjectNotFound", &ErrorConfig{Title: "Project not found"})
+
+		assert.ErrorIs(t, errLeaf, errMid)
+		assert.ErrorIs(t, errLeaf, errBase)
+		assert.ErrorIs(t, m.New(errLeaf), errBase)
+		assert.False(t, errors.Is(errBase, errLeaf))
+		assert.Equal(t, "ErrProjectNotFound", errLeaf.Error())
+
+		res := m.Build(m.New(errLeaf), LanguageEn)
+		assert.Equal(t, 404, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrProjectNotFound", res.ErrorInfo.Code)
+		assert.Equal(t, LogLevelWarn, res.ErrorInfo.LogLevel)
+		assert.Equal(t, "(Project not found)-in-en", res.ErrorInfo.Title)
+		assert.Equal(t, "(ErrProjectNotFound)-in-en", res.ErrorInfo.Message)
+
+		resolved := m.Registry().Resolve(m.GetErrorConfig(errLeaf))
+		assert.Equal(t, "extra", resolved.Extra)
+		assert.Equal(t, uint32(5), resolved.GRPCCode)
+		// The registered config is not modified
+		assert.Equal(t, 0, m.GetErrorConfig(errLeaf).Status)
+	})
+
+	t.Run("parent added for an external error", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errBase := m.Create("ErrConflict", &ErrorConfig{Status: 409})
+		_ = m.Add(errTest1, &ErrorConfig{Parent: errBase})
+
+		res := m.Build(errTest1, LanguageEn)
+		assert.Equal(t, 409, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrTest1", res.ErrorInfo.Code)
+	})
+
+	t.Run("unregistered parent or cyclic inheritance", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errChild := m.CreateChild(errTest2, "ErrChild", &ErrorConfig{})
+		assert.Equal(t, 500, m.Build(errChild, LanguageEn).ErrorInfo.Status)
+
+		cfg1 := &ErrorConfig{Status: 400}
+		cfg2 := &ErrorConfig{}
+		e1 := m.Create("ErrCycle1", cfg1)
+		e2 := m.CreateChild(e1, "ErrCycle2", cfg2)
+		cfg1.Parent = e2
+		assert.Equal(t, 400, m.Build(e2, LanguageEn).ErrorInfo.Status)
+	})
+
+	t.Run("global mapping", func(t *testing.T) {
+		initConfig(okConfig)
+		errBase := Create("ErrGlobalBase", &ErrorConfig{Status: 418})
+		errChild := CreateChild(errBase, "ErrGlobalChild", &ErrorConfig{})
+		defer func() {
+			Remove(errBase)
+			Remove(errChild)
+		}()
+
+		assert.ErrorIs(t, errChild, errBase)
+		assert.Equal(t, 418, Build(errChild, LanguageEn).ErrorInfo.Status)
+	})
+}
+
+type testDomainErr struct {
+	code string
+}
+
+func (e *testDomainErr) Error() string          { return "domain error" }
+func (e *testDomainErr) HTTPStatus() int        { return 409 }
+func (e *testDomainErr) ErrorCode() string      { return e.code }
+func (e *testDomainErr) LogLevel() LogLevel     { return LogLevelInfo }
+func (e *testDomainErr) TranslationKey() string { return "errors." + e.code }
+
+type testStatusOnlyErr struct{}
+
+func (e testStatusOnlyErr) Error() string   { return "status only" }
+func (e testStatusOnlyErr) HTTPStatus() int { return 429 }
+
+func Test_ErrorConfig_Discovery(t *testing.T) {
+	t.Run("all interfaces implemented", func(t *testing.T) {
+		m := NewManager(&Config{
+			TranslationFunc: func(lang Language, key string, params map[string]any) (string, error) {
+				return key, nil
+			},
+		})
+		res := m.Build(fmt.Errorf("wrapped: %w", &testDomainErr{code: "ErrConflict"}), LanguageEn)
+		assert.Equal(t, 409, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrConflict", res.ErrorInfo.Code)
+		assert.Equal(t, LogLevelInfo, res.ErrorInfo.LogLevel)
+		assert.Equal(t, "errors.ErrConflict", res.ErrorInfo.Message)
+	})
+
+	t.Run("some interfaces implemented", func(t *testing.T) {
+		m := NewManager(&Config{DefaultLogLevel: LogLevelError})
+		res := m.Build(testStatusOnlyErr{}, LanguageEn)
+		assert.Equal(t, 429, res.ErrorInfo.Status)
+		assert.Equal(t, "status only", res.ErrorInfo.Code)
+		assert.Equal(t, LogLevelError, res.ErrorInfo.LogLevel)
+	})
+
+	t.Run("registry mapping takes precedence", func(t *testing.T) {
+		m := NewManager(&Config{})
+		AddTypeWith[*testDomainErr](m, &ErrorConfig{Status: 400})
+		res := m.Build(&testDomainErr{code: "ErrConflict"}, LanguageEn)
+		assert.Equal(t, 400, res.ErrorInfo.Status)
+		assert.Equal(t, "domain error", res.ErrorInfo.Code)
+	})
+
+	t.Run("no interface implemented", func(t *testing.T) {
+		assert.Nil(t, discoverErrorConfig(errTest1))
+		assert.Nil(t, discoverErrorConfig(nil))
+	})
+}
@@ -1,20 +1,60 @@
 package goapperrors
 
+import "net/http"
+
 // ErrorInfo stores error information which can be used to return to client
 type ErrorInfo struct {
 	Status      int          `json:"status,omitempty"`
 	Code        string       `json:"code,omitempty"`
-	Source      any          `json:"source,omitempty"`
+	Source      any          `json:"source,omitempty"` // usually a *Source, any value is allowed
 	Title       string       `json:"title,omitempty"`
 	Message     string       `json:"message,omitempty"`
 	Cause       string       `json:"cause,omitempty"`
 	Debug       string       `json:"debug,omitempty"`
 	LogLevel    LogLevel     `json:"logLevel,omitempty"`
 	InnerErrors []*ErrorInfo `json:"errors,omitempty"`
+	// Params params of the error, they are not serialized by default
+	Params map[string]any `json:"-"`
+	// Extra extra data set in the error config, it is not serialized by default
+	Extra any `json:"-"`
+	// GRPCCode gRPC status code set in the error config, it is not serialized by default
+	GRPCCode uint32 `json:"-"`
+	// Headers HTTP response headers of the error, it is not serialized by default
+	Headers http.Header `json:"-"`
 
 	AssociatedError error `json:"-"`
 }
 
+// extensionMembers returns members for output formats supporting custom members.
+// The config `Extra` is merged if it is a map, otherwise it is set as the member `extra`.
+// Params, `Cause` and `Debug` are also set if not empty.
+func (e *ErrorInfo) extensionMembers() map[string]any {
+	members := make(map[string]any, len(e.Params))
+	switch extra := e.Extra.(type) {
+	case nil:
+	case map[string]any:
+		for k, v := range extra {
+			members[k] = v
+		}
+	case map[string]string:
+		for k, v := range extra {
+			members[k] = v
+		}
+	default:
+		members["extra"] = extra
+	}
+	for k, v := range e.Params {
+		members[k] = v
+	}
+	if e.Cause != "" {
+		members["cause"] = e.Cause
+	}
+	if e.Debug != "" {
+		members["debug"] = e.Debug
+	}
+	return members
+}
+
 // InfoBuilderFunc custom info builder function
 type InfoBuilderFunc func(AppError, *InfoBuilderConfig) *InfoBuilderResult
 
@@ -23,9 +63,11 @@ type InfoBuilderConfig struct {
 	ErrorConfig                                ErrorConfig
 	InfoBuilderFunc                            InfoBuilderFunc
 	Language                                   Language
+	FallbackLanguages                          []Language
 	ErrorSeparator                             string
 	TranslationFunc                            TranslationFunc
 	TranslateTitle                             bool
+	FormatParams                               bool
 	FallbackToErrorContentOnMissingTranslation bool
 }
 
@@ -37,6 +79,9 @@ type InfoBuilderResult struct {
 	TransMissingKeys []string
 	// TransMissingMainKey is set `true` if the main message key is missing
 	TransMissingMainKey bool
+	// Language language of the translated main message, it is one of the fallback languages
+	// if the message is missing in the requested language (`nil` if the main message is not translated)
+	Language Language
 }
 
 // InfoBuilderOption config setter for building error info
@@ -70,6 +115,23 @@ func InfoBuilderOptionTranslateTitle(translateTitle bool) InfoBuilderOption {
 	}
 }
 
+// InfoBuilderOptionFallbackLanguages sets languages to try in order when a translation is missing
+// in the requested language (default: the parents of the language and the default language, see
+// LanguageFallbacks). Passing no languages disables the fallback.
+func InfoBuilderOptionFallbackLanguages(langs ...Language) InfoBuilderOption {
+	return func(cfg *InfoBuilderConfig) {
+		cfg.FallbackLanguages = langs
+	}
+}
+
+// InfoBuilderOptionFormatParams sets flag indicating params are formatted for the build language
+// before translation (default: `true`), see ParamFormat
+func InfoBuilderOptionFormatParams(formatParams bool) InfoBuilderOption {
+	return func(cfg *InfoBuilderConfig) {
+		cfg.FormatParams = formatParams
+	}
+}
+
 // InfoBuilderOptionSeparator sets custom content separator
 func InfoBuilderOptionSeparator(errorSeparator string) InfoBuilderOption {
 	return func(cfg *InfoBuilderConfig) {
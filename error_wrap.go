@@ -10,15 +10,12 @@ import (
 
 // Wrap wraps an error with adding stack trace if configured
 func Wrap(err error) error {
-	if globalConfig.WrapFunc != nil {
-		return globalConfig.WrapFunc(err)
-	}
-	return goerrors.Wrap(err, 1)
+	return defaultManager.wrap(err, 1)
 }
 
 // Wrapf wraps an error by calling fmt.Errorf and adds stack trace if configured
 func Wrapf(format string, args ...any) error {
-	return Wrap(fmt.Errorf(format, args...)) //nolint:err113
+	return defaultManager.wrap(fmt.Errorf(format, args...), 1) //nolint:err113
 }
 
 // GetStackTrace gets stack trace stored in the error if there is
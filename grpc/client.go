@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// FromStatus reconstructs an AppError from a gRPC status created by Status or FromErrorInfo.
+// Codes of the status details are mapped to the errors registered in the manager, so errors.Is()
+// works across service boundaries when both sides share the same error definitions. If a code is
+// not registered, it is kept in a custom config of the returning error with the message of the
+// LocalizedMessage detail (or the status message) as content.
+// Params are restored from the metadata of the ErrorInfo details, inner errors are restored as
+// a ValidationError if the status has a BadRequest detail, or a MultiError otherwise. The field
+// violations of the BadRequest detail are mapped to the inner errors in order, the field is set
+// as the source of the inner error info and the description is used as content of unregistered codes.
+// The status error is set as the cause of the returning error.
+// Returns `nil` if the status is `nil`, `OK`, or has no ErrorInfo detail of the configured domain.
+func FromStatus(st *status.Status, options ...Option) goapperrors.AppError {
+	return newConfig(options).fromStatus(st)
+}
+
+func (cfg *Config) fromStatus(st *status.Status) goapperrors.AppError {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	var (
+		errInfos   []*errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+		message    = st.Message()
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errInfos = append(errInfos, d)
+		case *errdetails.BadRequest:
+			badRequest = d
+		case *errdetails.LocalizedMessage:
+			if d.Message != "" {
+				message = d.Message
+			}
+		}
+	}
+	if len(errInfos) == 0 || (cfg.Domain != "" && errInfos[0].Domain != cfg.Domain) {
+		return nil
+	}
+
+	m := cfg.Manager
+	mainInfo, innerInfos := errInfos[0], errInfos[1:]
+	errCfg := &goapperrors.ErrorConfig{
+		Status:   HTTPStatusFromCode(st.Code()),
+		Code:     mainInfo.Reason,
+		GRPCCode: uint32(st.Code()),
+	}
+
+	var appErr goapperrors.AppError
+	if len(innerInfos) > 0 {
+		innerErrs := make([]goapperrors.AppError, 0, len(innerInfos))
+		violations := badRequest.GetFieldViolations()
+		for i, innerInfo := range innerInfos {
+			if i >= len(violations) {
+				innerErrs = append(innerErrs, cfg.appErrorOf(innerInfo, errCfg, ""))
+				continue
+			}
+			innerErr := cfg.appErrorOf(innerInfo, errCfg, violations[i].GetDescription())
+			if field := violations[i].GetField(); field != "" {
+				_ = innerErr.WithCustomBuilder(sourceInfoBuilder(goapperrors.SourcePointer(field)))
+			}
+			innerErrs = append(innerErrs, innerErr)
+		}
+		if badRequest != nil {
+			appErr = m.NewValidationError(innerErrs...)
+		} else {
+			appErr = m.NewMultiError(innerErrs...)
+		}
+		// Multi errors don't use the config mappings, the local config is copied if found
+		if localErr := m.Registry().GetByCode(mainInfo.Reason); localErr != nil {
+			localCfg := m.Registry().Resolve(m.GetErrorConfig(localErr))
+			errCfg = &localCfg
+		}
+		_ = appErr.WithCustomConfig(errCfg)
+		for k, v := range mainInfo.Metadata {
+			_ = appErr.WithParam(k, v)
+		}
+	} else {
+		appErr = cfg.appErrorOf(mainInfo, errCfg, message)
+	}
+	return appErr.WithCause(st.Err())
+}
+
+// appErrorOf creates an AppError for the ErrorInfo detail. If the code is not registered,
+// the given config with the code set is used as the custom config, and the remote message
+// is used as the error content, so it is kept when there is no translation for the code.
+func (cfg *Config) appErrorOf(errInfo *errdetails.ErrorInfo, remoteCfg *goapperrors.ErrorConfig,
+	message string) goapperrors.AppError {
+	m := cfg.Manager
+	var appErr goapperrors.AppError
+	if localErr := m.Registry().GetByCode(errInfo.Reason); localErr != nil {
+		appErr = m.New(localErr)
+	} else {
+		errCfg := *remoteCfg
+		errCfg.Code = errInfo.Reason
+		if message == "" {
+			message = errInfo.Reason
+		}
+		appErr = m.New(errors.New(message)).WithCustomConfig(&errCfg)
+	}
+	for k, v := range errInfo.Metadata {
+		_ = appErr.WithParam(k, v)
+	}
+	return appErr
+}
+
+// sourceInfoBuilder returns an info builder setting the source on the error info built as usual
+func sourceInfoBuilder(source *goapperrors.Source) goapperrors.InfoBuilderFunc {
+	return func(appErr goapperrors.AppError, buildCfg *goapperrors.InfoBuilderConfig) *goapperrors.InfoBuilderResult {
+		result := appErr.Build(buildCfg.Language, func(cfg *goapperrors.InfoBuilderConfig) {
+			*cfg = *buildCfg
+			cfg.InfoBuilderFunc = nil
+		})
+		result.ErrorInfo.Source = source
+		return result
+	}
+}
+
+// fromError converts the error returned from a call if it is a status carrying error details
+func (cfg *Config) fromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if appErr := cfg.fromStatus(st); appErr != nil {
+		return appErr
+	}
+	return err
+}
+
+// UnaryClientInterceptor returns an interceptor converting errors returned from unary calls
+// to AppErrors. See FromStatus for more details.
+func UnaryClientInterceptor(options ...Option) grpc.UnaryClientInterceptor {
+	cfg := newConfig(options)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return cfg.fromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns an interceptor converting errors returned from stream calls
+// to AppErrors. See FromStatus for more details.
+func StreamClientInterceptor(options ...Option) grpc.StreamClientInterceptor {
+	cfg := newConfig(options)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, cfg.fromError(err)
+		}
+		return &clientStream{ClientStream: stream, cfg: cfg}, nil
+	}
+}
+
+// clientStream converts errors returned when receiving messages
+type clientStream struct {
+	grpc.ClientStream
+	cfg *Config
+}
+
+// RecvMsg implements grpc.ClientStream
+func (s *clientStream) RecvMsg(m any) error {
+	return s.cfg.fromError(s.ClientStream.RecvMsg(m))
+}
@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+func Test_ClientInterceptors(t *testing.T) {
+	// Server and client share the same error definitions via separate managers
+	mServer := goapperrors.NewManager(&goapperrors.Config{})
+	errServerNotFound := mServer.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+	errServerRequired := mServer.Create("ErrRequired", &goapperrors.ErrorConfig{})
+	errServerOnly := mServer.Create("ErrServerOnly", &goapperrors.ErrorConfig{Status: http.StatusConflict})
+
+	mClient := goapperrors.NewManager(&goapperrors.Config{})
+	errClientNotFound := mClient.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+	errClientRequired := mClient.Create("ErrRequired", &goapperrors.ErrorConfig{})
+
+	dial := func(t *testing.T, err error) healthpb.HealthClient {
+		conn := startTestServer(t, err, []grpc.ServerOption{
+			grpc.UnaryInterceptor(UnaryServerInterceptor(OptionManager(mServer), OptionDomain("svc"))),
+			grpc.StreamInterceptor(StreamServerInterceptor(OptionManager(mServer), OptionDomain("svc"))),
+		},
+			grpc.WithUnaryInterceptor(UnaryClientInterceptor(OptionManager(mClient), OptionDomain("svc"))),
+			grpc.WithStreamInterceptor(StreamClientInterceptor(OptionManager(mClient), OptionDomain("svc"))),
+		)
+		return healthpb.NewHealthClient(conn)
+	}
+
+	t.Run("registered code", func(t *testing.T) {
+		client := dial(t, mServer.New(errServerNotFound).WithParam("projectID", "p1"))
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+		var appErr goapperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.ErrorIs(t, err, errClientNotFound)
+		assert.Equal(t, map[string]any{"projectID": "p1"}, appErr.Params())
+		assert.Equal(t, codes.NotFound, status.Code(appErr.Cause()))
+
+		res := mClient.Build(err, goapperrors.LanguageEn)
+		assert.Equal(t, http.StatusNotFound, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrProjectNotFound", res.ErrorInfo.Code)
+	})
+
+	t.Run("unregistered code", func(t *testing.T) {
+		client := dial(t, errServerOnly)
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		res := mClient.Build(err, goapperrors.LanguageEn)
+		assert.Equal(t, http.StatusConflict, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrServerOnly", res.ErrorInfo.Code)
+		assert.Equal(t, uint32(codes.AlreadyExists), res.ErrorInfo.GRPCCode)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := dial(t, mServer.NewValidationError(
+			mServer.New(errServerRequired).WithParam("field", "name"),
+			mServer.New(errServerOnly),
+		))
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		vldErr := goapperrors.AsMultiError(err.(goapperrors.AppError))
+		assert.NotNil(t, vldErr)
+		assert.ErrorIs(t, err, errClientRequired)
+
+		res := vldErr.Build(goapperrors.LanguageEn)
+		assert.Equal(t, http.StatusBadRequest, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrValidation", res.ErrorInfo.Code)
+		assert.Equal(t, 2, len(res.ErrorInfo.InnerErrors))
+		assert.Equal(t, "ErrRequired", res.ErrorInfo.InnerErrors[0].Code)
+		assert.Equal(t, map[string]any{"field": "name"}, res.ErrorInfo.InnerErrors[0].Params)
+		assert.Equal(t, "ErrServerOnly", res.ErrorInfo.InnerErrors[1].Code)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		client := dial(t, errServerNotFound)
+		stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, errClientNotFound)
+	})
+
+	t.Run("status without details is kept", func(t *testing.T) {
+		client := dial(t, status.Error(codes.Aborted, "aborted"))
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		var appErr goapperrors.AppError
+		assert.False(t, errors.As(err, &appErr))
+	})
+}
+
+func Test_FromStatus(t *testing.T) {
+	assert.Nil(t, FromStatus(nil))
+	assert.Nil(t, FromStatus(status.New(codes.OK, "")))
+	assert.Nil(t, FromStatus(status.New(codes.Internal, "no details")))
+
+	st := Status(goapperrors.NewManager(&goapperrors.Config{}).Create("ErrX", &goapperrors.ErrorConfig{}), nil,
+		OptionDomain("a"))
+	assert.Nil(t, FromStatus(st, OptionDomain("b")))
+	assert.NotNil(t, FromStatus(st, OptionDomain("a")))
+	// Not registered in the global manager, the code is kept in the custom config
+	assert.Equal(t, "ErrX", FromStatus(st, OptionDomain("a")).CustomConfig().Code)
+	assert.NoError(t, newConfig(nil).fromError(nil))
+	assert.Equal(t, io.EOF, newConfig(nil).fromError(io.EOF))
+
+	// Remote messages and field violations are restored
+	m := goapperrors.NewManager(&goapperrors.Config{})
+	errRequired := m.Create("ErrRequired", &goapperrors.ErrorConfig{})
+	st = FromErrorInfo(&goapperrors.ErrorInfo{
+		Status:  http.StatusBadRequest,
+		Code:    "ErrValidation",
+		Message: "Données invalides",
+		InnerErrors: []*goapperrors.ErrorInfo{
+			{Code: "ErrRequired", Message: "Champ requis", Source: goapperrors.SourcePointer("items[0].name")},
+			{Code: "ErrTooLong", Message: "Trop long", Source: "description"},
+			{Code: "ErrUnknown", Message: "Erreur"},
+		},
+	}, goapperrors.LanguageFr, OptionManager(m))
+	appErr := FromStatus(st, OptionManager(m))
+	assert.ErrorIs(t, appErr, errRequired)
+	innerInfos := m.Build(appErr, goapperrors.LanguageFr).ErrorInfo.InnerErrors
+	assert.Equal(t, 3, len(innerInfos))
+	assert.Equal(t, goapperrors.SourcePointer("/items/0/name"), innerInfos[0].Source)
+	assert.Equal(t, "ErrTooLong", innerInfos[1].Code)
+	assert.Equal(t, "Trop long", innerInfos[1].Message)
+	assert.Equal(t, goapperrors.SourcePointer("/description"), innerInfos[1].Source)
+	assert.Equal(t, "Erreur", innerInfos[2].Message)
+	assert.Nil(t, innerInfos[2].Source)
+
+	st = FromErrorInfo(&goapperrors.ErrorInfo{Status: http.StatusConflict, Code: "ErrRemote", Message: "Conflit"},
+		goapperrors.LanguageFr)
+	assert.Equal(t, "Conflit", m.Build(FromStatus(st, OptionManager(m)), goapperrors.LanguageFr).ErrorInfo.Message)
+}
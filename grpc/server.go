@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// UnaryServerInterceptor returns an interceptor converting errors returned by unary handlers
+// to gRPC statuses. Error info is built in the language taken from the request metadata.
+func UnaryServerInterceptor(options ...Option) grpc.UnaryServerInterceptor {
+	cfg := newConfig(options)
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, cfg.status(err, cfg.language(ctx)).Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns an interceptor converting errors returned by stream handlers
+// to gRPC statuses. Error info is built in the language taken from the request metadata.
+func StreamServerInterceptor(options ...Option) grpc.StreamServerInterceptor {
+	cfg := newConfig(options)
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return cfg.status(err, cfg.language(ss.Context())).Err()
+		}
+		return nil
+	}
+}
+
+// language returns the language of the request from the incoming metadata. Values like
+// `fr-CH, fr;q=0.9` are accepted, the best supported language is returned if a language matcher
+// is configured, otherwise the language with the highest quality. The default language of
+// the manager is returned if no language is found.
+func (cfg *Config) language(ctx context.Context) goapperrors.Language {
+	md, _ := metadata.FromIncomingContext(ctx)
+	acceptLang := strings.Join(md.Get(cfg.LanguageMetadataKey), ",")
+	if cfg.LanguageMatcher != nil {
+		lang, _ := cfg.LanguageMatcher.Match(acceptLang)
+		return lang
+	}
+	langs, _ := goapperrors.ParseAcceptLanguageAsStr(acceptLang)
+	for _, lang := range langs {
+		if lang != "mul" && lang != "und" {
+			return lang
+		}
+	}
+	return cfg.Manager.Config().DefaultLanguage
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+type testHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	err error
+}
+
+func (s *testHealthServer) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return nil, s.err
+}
+
+func (s *testHealthServer) Watch(*healthpb.HealthCheckRequest, healthpb.Health_WatchServer) error {
+	return s.err
+}
+
+// startTestServer starts a server over an in-memory connection, then returns a client connected to it
+func startTestServer(t *testing.T, err error, serverOpts []grpc.ServerOption,
+	dialOpts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(serverOpts...)
+	healthpb.RegisterHealthServer(server, &testHealthServer{err: err})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	dialOpts = append(dialOpts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, dialErr := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	assert.NoError(t, dialErr)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func Test_ServerInterceptors(t *testing.T) {
+	m := goapperrors.NewManager(&goapperrors.Config{
+		DefaultLanguage: "de",
+		TranslationFunc: testTranslate,
+	})
+	errNotFound := m.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+
+	conn := startTestServer(t, m.New(errNotFound), []grpc.ServerOption{
+		grpc.UnaryInterceptor(UnaryServerInterceptor(OptionManager(m))),
+		grpc.StreamInterceptor(StreamServerInterceptor(OptionManager(m))),
+	})
+	client := healthpb.NewHealthClient(conn)
+
+	t.Run("unary with language in metadata", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "fr-CH, fr;q=0.9")
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "ErrProjectNotFound-in-fr-CH", st.Message())
+		assert.Equal(t, "ErrProjectNotFound", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		assert.Equal(t, "fr-CH", st.Details()[1].(*errdetails.LocalizedMessage).Locale)
+	})
+
+	t.Run("stream with default language", func(t *testing.T) {
+		stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "ErrProjectNotFound-in-de", st.Message())
+	})
+}
+
+func Test_Config_language(t *testing.T) {
+	m := goapperrors.NewManager(&goapperrors.Config{DefaultLanguage: goapperrors.LanguageDe})
+	ctxOf := func(values ...string) context.Context {
+		md := metadata.MD{}
+		md.Append(DefaultLanguageMetadataKey, values...)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	cfg := newConfig([]Option{OptionManager(m)})
+	assert.Equal(t, "fr-CH", cfg.language(ctxOf("en;q=0.5, fr-CH")))
+	assert.Equal(t, "ja", cfg.language(ctxOf("*", "ja;q=0.8")))
+	assert.Equal(t, goapperrors.LanguageDe, cfg.language(ctxOf("not a language")))
+	assert.Equal(t, goapperrors.LanguageDe, cfg.language(context.Background()))
+
+	cfg = newConfig([]Option{OptionManager(m), OptionLanguageMatcher(goapperrors.NewLanguageMatcher("en", "fr"))})
+	assert.Equal(t, "fr", cfg.language(ctxOf("de;q=0.9, fr-CH;q=0.5")))
+	assert.Equal(t, "en", cfg.language(ctxOf("ja")))
+}
@@ -0,0 +1,250 @@
+// Package grpc converts application errors to gRPC statuses and provides interceptors
+// for gRPC servers and clients.
+package grpc
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// DefaultLanguageMetadataKey default metadata key of the request language
+const DefaultLanguageMetadataKey = "accept-language"
+
+// Config config of the conversion between application errors and gRPC statuses
+type Config struct {
+	// Manager manager used to build error info (default: the global one)
+	Manager *goapperrors.Manager
+	// Domain domain set in the ErrorInfo details, e.g. `myservice.example.com`
+	Domain string
+	// LanguageMetadataKey metadata key of the request language (default: `accept-language`)
+	LanguageMetadataKey string
+	// LanguageMatcher matcher of the supported languages, if set the request language is
+	// the best supported language matching the metadata
+	LanguageMatcher *goapperrors.LanguageMatcher
+}
+
+// Option config setter
+type Option func(*Config)
+
+// OptionManager sets the manager used to build error info
+func OptionManager(manager *goapperrors.Manager) Option {
+	return func(cfg *Config) {
+		cfg.Manager = manager
+	}
+}
+
+// OptionDomain sets the domain of the ErrorInfo details
+func OptionDomain(domain string) Option {
+	return func(cfg *Config) {
+		cfg.Domain = domain
+	}
+}
+
+// OptionLanguageMetadataKey sets the metadata key of the request language
+func OptionLanguageMetadataKey(key string) Option {
+	return func(cfg *Config) {
+		cfg.LanguageMetadataKey = key
+	}
+}
+
+// OptionLanguageMatcher sets the language of a request to the best supported language
+// matching the language metadata
+func OptionLanguageMatcher(matcher *goapperrors.LanguageMatcher) Option {
+	return func(cfg *Config) {
+		cfg.LanguageMatcher = matcher
+	}
+}
+
+func newConfig(options []Option) *Config {
+	cfg := &Config{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+	if cfg.Manager == nil {
+		cfg.Manager = goapperrors.Default()
+	}
+	if cfg.LanguageMetadataKey == "" {
+		cfg.LanguageMetadataKey = DefaultLanguageMetadataKey
+	}
+	return cfg
+}
+
+// Status builds error info of the error in the language, then converts it to a gRPC status.
+// The locale of the LocalizedMessage detail is the language the message is translated in,
+// which can be a fallback language of the given one, or the given language if the message
+// is not translated.
+// Errors which are already gRPC statuses are returned as is. Returns `nil` if the error is `nil`.
+func Status(err error, lang goapperrors.Language, options ...Option) *status.Status {
+	return newConfig(options).status(err, lang)
+}
+
+func (cfg *Config) status(err error, lang goapperrors.Language) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if statusErr, ok := err.(interface{ GRPCStatus() *status.Status }); ok { //nolint:errorlint
+		return statusErr.GRPCStatus()
+	}
+	result := cfg.Manager.Build(err, lang)
+	if result.Language != nil {
+		lang = result.Language
+	}
+	return cfg.fromErrorInfo(result.ErrorInfo, lang)
+}
+
+// FromErrorInfo converts the error info to a gRPC status.
+// The status code is `GRPCCode` of the error config if set, otherwise it is derived from `Status`.
+// The status contains the following details:
+//   - errdetails.ErrorInfo with the error code as reason and params as metadata
+//   - errdetails.LocalizedMessage with the built message and language
+//   - errdetails.BadRequest with a field violation for every inner error if the status code is
+//     `InvalidArgument` (e.g. ValidationError)
+//   - errdetails.ErrorInfo for every inner error, in the same order as the inner errors
+func FromErrorInfo(errInfo *goapperrors.ErrorInfo, lang goapperrors.Language, options ...Option) *status.Status {
+	return newConfig(options).fromErrorInfo(errInfo, lang)
+}
+
+func (cfg *Config) fromErrorInfo(errInfo *goapperrors.ErrorInfo, lang goapperrors.Language) *status.Status {
+	code := codes.Code(errInfo.GRPCCode)
+	if code == codes.OK {
+		code = CodeFromHTTPStatus(errInfo.Status)
+	}
+
+	details := []protoadapt.MessageV1{
+		cfg.errorInfoDetail(errInfo),
+		&errdetails.LocalizedMessage{Locale: languageString(lang), Message: errInfo.Message},
+	}
+	if len(errInfo.InnerErrors) > 0 {
+		if code == codes.InvalidArgument {
+			badRequest := &errdetails.BadRequest{}
+			for _, inErr := range errInfo.InnerErrors {
+				badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       sourceField(inErr.Source),
+					Description: inErr.Message,
+				})
+			}
+			details = append(details, badRequest)
+		}
+		for _, inErr := range errInfo.InnerErrors {
+			details = append(details, cfg.errorInfoDetail(inErr))
+		}
+	}
+
+	st := status.New(code, errInfo.Message)
+	if stWithDetails, err := st.WithDetails(details...); err == nil {
+		return stWithDetails
+	}
+	return st
+}
+
+func (cfg *Config) errorInfoDetail(errInfo *goapperrors.ErrorInfo) *errdetails.ErrorInfo {
+	detail := &errdetails.ErrorInfo{
+		Reason: errInfo.Code,
+		Domain: cfg.Domain,
+	}
+	if len(errInfo.Params) > 0 {
+		detail.Metadata = make(map[string]string, len(errInfo.Params))
+		for k, v := range errInfo.Params {
+			detail.Metadata[k] = fmt.Sprint(v)
+		}
+	}
+	return detail
+}
+
+// sourceField converts the source of an error to the field path of a field violation
+func sourceField(source any) string {
+	switch s := source.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	}
+	if src := goapperrors.ParseSource(source); src != nil {
+		return src.Field()
+	}
+	return fmt.Sprint(source)
+}
+
+func languageString(lang goapperrors.Language) string {
+	if lang == nil {
+		return ""
+	}
+	return fmt.Sprint(lang)
+}
+
+// CodeFromHTTPStatus returns the gRPC code corresponding to the HTTP status.
+// The mapping follows the one documented in google.rpc.Code.
+func CodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return codes.OutOfRange
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499: //nolint:mnd
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	switch {
+	case httpStatus >= 400 && httpStatus < 500:
+		return codes.FailedPrecondition
+	case httpStatus >= 500 && httpStatus < 600:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// HTTPStatusFromCode returns the HTTP status corresponding to the gRPC code.
+// The mapping follows the one documented in google.rpc.Code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 //nolint:mnd
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
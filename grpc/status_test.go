@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+func testTranslate(lang goapperrors.Language, key string, params map[string]any) (string, error) {
+	return key + "-in-" + languageString(lang), nil
+}
+
+func Test_Status(t *testing.T) {
+	m := goapperrors.NewManager(&goapperrors.Config{TranslationFunc: testTranslate})
+	errNotFound := m.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+	errQuota := m.Create("ErrQuotaExceeded", &goapperrors.ErrorConfig{
+		Status:   http.StatusTooManyRequests,
+		GRPCCode: uint32(codes.Unavailable),
+	})
+	errRequired := m.Create("ErrRequired", &goapperrors.ErrorConfig{Status: http.StatusBadRequest})
+
+	t.Run("code derived from HTTP status", func(t *testing.T) {
+		appErr := m.New(errNotFound).WithParam("projectID", 12)
+		st := Status(appErr, "fr", OptionManager(m), OptionDomain("example.com"))
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "ErrProjectNotFound-in-fr", st.Message())
+
+		details := st.Details()
+		assert.Equal(t, 2, len(details))
+		errInfo := details[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, "ErrProjectNotFound", errInfo.Reason)
+		assert.Equal(t, "example.com", errInfo.Domain)
+		assert.Equal(t, map[string]string{"projectID": "12"}, errInfo.Metadata)
+		localized := details[1].(*errdetails.LocalizedMessage)
+		assert.Equal(t, "fr", localized.Locale)
+		assert.Equal(t, "ErrProjectNotFound-in-fr", localized.Message)
+	})
+
+	t.Run("locale of fallback language", func(t *testing.T) {
+		mFallback := goapperrors.NewManager(&goapperrors.Config{
+			DefaultLanguage: goapperrors.LanguageEn,
+			TranslationFunc: func(lang goapperrors.Language, key string, params map[string]any) (string, error) {
+				if languageString(lang) != "fr" {
+					return "", errors.New("missing") //nolint:err113
+				}
+				return testTranslate(lang, key, params)
+			},
+		})
+		errFallback := mFallback.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+		st := Status(errFallback, "fr-CH", OptionManager(mFallback))
+		localized := st.Details()[1].(*errdetails.LocalizedMessage)
+		assert.Equal(t, "fr", localized.Locale)
+		assert.Equal(t, "ErrProjectNotFound-in-fr", localized.Message)
+	})
+
+	t.Run("locale of untranslated message", func(t *testing.T) {
+		mNoTrans := goapperrors.NewManager(&goapperrors.Config{})
+		errNoTrans := mNoTrans.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+		localized := Status(errNoTrans, "fr", OptionManager(mNoTrans)).Details()[1].(*errdetails.LocalizedMessage)
+		assert.Equal(t, "fr", localized.Locale)
+		assert.Equal(t, "ErrProjectNotFound", localized.Message)
+	})
+
+	t.Run("code from config", func(t *testing.T) {
+		st := Status(errQuota, "en", OptionManager(m))
+		assert.Equal(t, codes.Unavailable, st.Code())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		vldErr := m.NewValidationError(
+			m.New(errRequired).WithParam("field", "name").WithCustomBuilder(
+				func(e goapperrors.AppError, cfg *goapperrors.InfoBuilderConfig) *goapperrors.InfoBuilderResult {
+					return &goapperrors.InfoBuilderResult{ErrorInfo: &goapperrors.ErrorInfo{
+						Code: "ErrRequired", Message: "name is required", Source: "name",
+						Params: e.Params(),
+					}}
+				}),
+			m.New(errRequired).WithCustomBuilder(
+				func(e goapperrors.AppError, cfg *goapperrors.InfoBuilderConfig) *goapperrors.InfoBuilderResult {
+					return &goapperrors.InfoBuilderResult{ErrorInfo: &goapperrors.ErrorInfo{
+						Code: "ErrRequired", Source: goapperrors.SourcePointer("items[0].name"),
+					}}
+				}),
+		)
+		st := Status(vldErr, "en", OptionManager(m))
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		details := st.Details()
+		assert.Equal(t, 5, len(details))
+		badRequest := details[2].(*errdetails.BadRequest)
+		assert.Equal(t, 2, len(badRequest.FieldViolations))
+		assert.Equal(t, "name", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "name is required", badRequest.FieldViolations[0].Description)
+		assert.Equal(t, "/items/0/name", badRequest.FieldViolations[1].Field)
+		assert.Equal(t, "ErrRequired", details[3].(*errdetails.ErrorInfo).Reason)
+		assert.Equal(t, map[string]string{"field": "name"}, details[3].(*errdetails.ErrorInfo).Metadata)
+		assert.Equal(t, "ErrRequired", details[4].(*errdetails.ErrorInfo).Reason)
+	})
+
+	t.Run("status error and nil error", func(t *testing.T) {
+		stErr := status.Error(codes.Aborted, "aborted")
+		assert.Equal(t, codes.Aborted, Status(stErr, "en").Code())
+		assert.Nil(t, Status(nil, "en"))
+	})
+
+	t.Run("unknown error with global manager", func(t *testing.T) {
+		st := Status(errors.New("boom"), nil)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "", st.Details()[1].(*errdetails.LocalizedMessage).Locale)
+	})
+}
+
+func Test_CodeMapping(t *testing.T) {
+	assert.Equal(t, codes.InvalidArgument, CodeFromHTTPStatus(http.StatusBadRequest))
+	assert.Equal(t, codes.AlreadyExists, CodeFromHTTPStatus(http.StatusConflict))
+	assert.Equal(t, codes.FailedPrecondition, CodeFromHTTPStatus(http.StatusGone))
+	assert.Equal(t, codes.Internal, CodeFromHTTPStatus(http.StatusBadGateway))
+	assert.Equal(t, codes.Unknown, CodeFromHTTPStatus(0))
+
+	assert.Equal(t, http.StatusBadRequest, HTTPStatusFromCode(codes.FailedPrecondition))
+	assert.Equal(t, http.StatusConflict, HTTPStatusFromCode(codes.Aborted))
+	assert.Equal(t, 499, HTTPStatusFromCode(codes.Canceled))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatusFromCode(codes.DataLoss))
+	for _, httpStatus := range []int{400, 401, 403, 404, 409, 429, 501, 503, 504} {
+		assert.Equal(t, httpStatus, HTTPStatusFromCode(CodeFromHTTPStatus(httpStatus)))
+	}
+}
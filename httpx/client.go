@@ -0,0 +1,268 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+var (
+	// ErrUpstream error used when an error response of an upstream service has no error code,
+	// e.g. the body is empty or not in a supported format
+	ErrUpstream = errors.New("ErrUpstream")
+)
+
+// defaultMaxBodySize max size of error response bodies to be decoded
+const defaultMaxBodySize = 1 << 20
+
+// ResponseError describes an error response of an upstream service.
+// It is set as the cause of the AppErrors returned by DecodeResponse.
+type ResponseError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// ErrorInfo error info decoded from the response body, `nil` if the body can't be decoded
+	ErrorInfo *goapperrors.ErrorInfo
+}
+
+// Error implements `error` interface
+func (e *ResponseError) Error() string {
+	var sb strings.Builder
+	if e.Method != "" {
+		sb.WriteString(e.Method)
+		sb.WriteString(" ")
+	}
+	sb.WriteString(e.URL)
+	sb.WriteString(": ")
+	fmt.Fprintf(&sb, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.ErrorInfo != nil {
+		if e.ErrorInfo.Code != "" {
+			sb.WriteString(": ")
+			sb.WriteString(e.ErrorInfo.Code)
+		}
+		if e.ErrorInfo.Message != "" {
+			sb.WriteString(": ")
+			sb.WriteString(e.ErrorInfo.Message)
+		}
+	}
+	return sb.String()
+}
+
+// ClientConfig config of decoding error responses
+type ClientConfig struct {
+	// Manager manager whose registry is used to map error codes (default: the global one)
+	Manager *goapperrors.Manager
+	// MaxBodySize max number of bytes read from error response bodies (default: 1MB)
+	MaxBodySize int64
+}
+
+// ClientOption config setter
+type ClientOption func(*ClientConfig)
+
+// ClientOptionManager sets the manager
+func ClientOptionManager(manager *goapperrors.Manager) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.Manager = manager
+	}
+}
+
+// ClientOptionMaxBodySize sets the max number of bytes read from error response bodies
+func ClientOptionMaxBodySize(size int64) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.MaxBodySize = size
+	}
+}
+
+func newClientConfig(options []ClientOption) *ClientConfig {
+	cfg := &ClientConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+	if cfg.Manager == nil {
+		cfg.Manager = goapperrors.Default()
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = defaultMaxBodySize
+	}
+	return cfg
+}
+
+// DecodeResponse converts an error response (status 4xx or 5xx) to an AppError, returns `nil` for
+// other responses, e.g. 2xx, redirects and `304 Not Modified`.
+// The body is decoded as problem details if its media type is `application/problem+json`,
+// otherwise as native error info JSON. Codes are mapped to the errors registered in the manager,
+// so errors.Is() works across service boundaries when both sides share the same error definitions.
+// If a code is not registered, the remote status, code, title and message are kept in a custom
+// config of the returning error. If the body has no error code, ErrUpstream is used.
+// Inner errors are restored as a ValidationError if the code is the validation error code of
+// the manager, or a MultiError otherwise, their sources are kept in the built error info. A ResponseError is set as the cause of the returning error.
+// The body is read but not closed.
+func DecodeResponse(resp *http.Response, options ...ClientOption) error {
+	return newClientConfig(options).decodeResponse(resp)
+}
+
+func (cfg *ClientConfig) decodeResponse(resp *http.Response) error {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+	}
+	if resp.Request != nil {
+		respErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			respErr.URL = resp.Request.URL.Redacted()
+		}
+	}
+	if resp.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, cfg.MaxBodySize))
+		if err == nil {
+			respErr.ErrorInfo = decodeErrorInfo(resp.Header.Get("Content-Type"), body)
+		}
+	}
+
+	var appErr goapperrors.AppError
+	if respErr.ErrorInfo == nil || respErr.ErrorInfo.Code == "" {
+		appErr = cfg.Manager.New(ErrUpstream)
+		if cfg.Manager.GetErrorConfig(ErrUpstream) == nil {
+			_ = appErr.WithCustomConfig(&goapperrors.ErrorConfig{
+				Status:   resp.StatusCode,
+				LogLevel: goapperrors.LogLevelError,
+			})
+		}
+	} else {
+		appErr = cfg.appErrorOf(respErr.ErrorInfo, resp.StatusCode)
+	}
+	return appErr.WithCause(respErr)
+}
+
+// decodeErrorInfo decodes the response body, returns `nil` if the body is not in a supported format
+func decodeErrorInfo(contentType string, body []byte) *goapperrors.ErrorInfo {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == MediaTypeProblemJSON {
+		problem, err := goapperrors.ParseProblemDetails(bytes.NewReader(body))
+		if err != nil {
+			return nil
+		}
+		return problem.ErrorInfo()
+	}
+	errInfo := &goapperrors.ErrorInfo{}
+	if err := json.Unmarshal(body, errInfo); err != nil {
+		return nil
+	}
+	return errInfo
+}
+
+// appErrorOf creates an AppError for the remote error info. If the code is not registered,
+// the remote info is kept in the custom config of the error.
+func (cfg *ClientConfig) appErrorOf(errInfo *goapperrors.ErrorInfo, status int) goapperrors.AppError {
+	m := cfg.Manager
+	if errInfo.Status != 0 {
+		status = errInfo.Status
+	}
+	remoteCfg := &goapperrors.ErrorConfig{
+		Status: status,
+		Code:   errInfo.Code,
+		Title:  errInfo.Title,
+	}
+	localErr := m.Registry().GetByCode(errInfo.Code)
+
+	var appErr goapperrors.AppError
+	switch {
+	case len(errInfo.InnerErrors) > 0:
+		innerErrs := make([]goapperrors.AppError, 0, len(errInfo.InnerErrors))
+		for _, innerInfo := range errInfo.InnerErrors {
+			innerErrs = append(innerErrs, cfg.appErrorOf(innerInfo, status))
+		}
+		if errInfo.Code == m.Config().DefaultValidationErrorCode {
+			appErr = m.NewValidationError(innerErrs...)
+		} else {
+			appErr = m.NewMultiError(innerErrs...)
+		}
+		// Multi errors don't use the config mappings, the local config is copied if found
+		if localErr != nil {
+			localCfg := m.Registry().Resolve(m.GetErrorConfig(localErr))
+			remoteCfg = &localCfg
+		}
+		_ = appErr.WithCustomConfig(remoteCfg)
+	case localErr != nil:
+		appErr = m.New(localErr)
+	default:
+		// The remote message is used as the error content, so it is kept when there is
+		// no translation for the code
+		content := errInfo.Message
+		if content == "" {
+			content = errInfo.Code
+		}
+		appErr = m.New(errors.New(content)).WithCustomConfig(remoteCfg)
+	}
+	if source := goapperrors.ParseSource(errInfo.Source); source != nil && len(errInfo.InnerErrors) == 0 {
+		_ = appErr.WithCustomBuilder(sourceInfoBuilder(source))
+	}
+	for k, v := range errInfo.Params {
+		_ = appErr.WithParam(k, v)
+	}
+	return appErr
+}
+
+// sourceInfoBuilder returns an info builder setting the source on the error info built as usual
+func sourceInfoBuilder(source *goapperrors.Source) goapperrors.InfoBuilderFunc {
+	return func(appErr goapperrors.AppError, buildCfg *goapperrors.InfoBuilderConfig) *goapperrors.InfoBuilderResult {
+		result := appErr.Build(buildCfg.Language, func(cfg *goapperrors.InfoBuilderConfig) {
+			*cfg = *buildCfg
+			cfg.InfoBuilderFunc = nil
+		})
+		result.ErrorInfo.Source = source
+		return result
+	}
+}
+
+// roundTripper converts error responses to AppErrors
+type roundTripper struct {
+	next http.RoundTripper
+	cfg  *ClientConfig
+}
+
+// NewRoundTripper returns a RoundTripper converting error responses (status 4xx or 5xx) of the next
+// one to AppErrors (default: http.DefaultTransport). Other responses, including redirects, are
+// returned as is. The body of error responses is closed and the AppError is returned instead of
+// the response, http.Client wraps it in a *url.Error which supports errors.Is() and errors.As().
+// See DecodeResponse for more details.
+//
+// Note that unlike the http.RoundTripper contract, responses are interpreted and error responses
+// are not returned. Use it only for clients which want this behavior, otherwise call DecodeResponse
+// on the responses.
+//
+// Example:
+//
+//	client := &http.Client{Transport: httpx.NewRoundTripper(nil)}
+//	resp, err := client.Get("http://project-service/projects/1")
+//	if errors.Is(err, ErrProjectNotFound) {
+//		...
+//	}
+func NewRoundTripper(next http.RoundTripper, options ...ClientOption) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{next: next, cfg: newClientConfig(options)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if decodeErr := rt.cfg.decodeResponse(resp); decodeErr != nil {
+		_ = resp.Body.Close()
+		return nil, decodeErr
+	}
+	return resp, nil
+}
@@ -0,0 +1,211 @@
+// Package httpx provides net/http helpers writing application errors as HTTP responses.
+package httpx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// ErrPanic is the base error of AppErrors created from recovered panics.
+// It can be mapped like other errors to customize the responses, by default the status is 500
+// and the log level is `LogLevelError`.
+var ErrPanic = errors.New("ErrPanic")
+
+// HandlerFunc HTTP handler function returning an error
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// LogFunc logging hook called for errors whose log level is not `LogLevelNone`
+type LogFunc func(r *http.Request, err error, result *goapperrors.InfoBuilderResult)
+
+// Config config of ErrorHandler
+type Config struct {
+	// Manager manager used to build error info (default: the global one)
+	Manager *goapperrors.Manager
+	// LanguageFunc determines the language of a request
+	// (default: the first language of header Accept-Language or the default language of the manager)
+	LanguageFunc func(r *http.Request) goapperrors.Language
+	// LogFunc logging hook called for errors whose log level is not `LogLevelNone`
+	LogFunc LogFunc
+	// BuildOptions options used when build error info
+	BuildOptions []goapperrors.InfoBuilderOption
+	// Renderers renderers selected by the request header Accept (default: DefaultRenderers())
+	Renderers *Renderers
+}
+
+// Option config setter
+type Option func(*Config)
+
+// OptionManager sets the manager used to build error info
+func OptionManager(manager *goapperrors.Manager) Option {
+	return func(cfg *Config) {
+		cfg.Manager = manager
+	}
+}
+
+// OptionLanguageFunc sets function determining the language of a request
+func OptionLanguageFunc(languageFunc func(r *http.Request) goapperrors.Language) Option {
+	return func(cfg *Config) {
+		cfg.LanguageFunc = languageFunc
+	}
+}
+
+// OptionLanguageMatcher sets the language of a request to the best supported language
+// matching header Accept-Language
+func OptionLanguageMatcher(matcher *goapperrors.LanguageMatcher) Option {
+	return func(cfg *Config) {
+		cfg.LanguageFunc = func(r *http.Request) goapperrors.Language {
+			lang, _ := matcher.Match(r.Header.Get("Accept-Language"))
+			return lang
+		}
+	}
+}
+
+// OptionLogFunc sets the logging hook
+func OptionLogFunc(logFunc LogFunc) Option {
+	return func(cfg *Config) {
+		cfg.LogFunc = logFunc
+	}
+}
+
+// OptionBuildOptions sets options used when build error info
+func OptionBuildOptions(buildOptions ...goapperrors.InfoBuilderOption) Option {
+	return func(cfg *Config) {
+		cfg.BuildOptions = buildOptions
+	}
+}
+
+// OptionRenderers sets the renderers selected by the request header Accept
+func OptionRenderers(renderers *Renderers) Option {
+	return func(cfg *Config) {
+		cfg.Renderers = renderers
+	}
+}
+
+// ErrorHandler writes errors as HTTP responses
+type ErrorHandler struct {
+	cfg *Config
+}
+
+// NewErrorHandler creates a new ErrorHandler
+func NewErrorHandler(options ...Option) *ErrorHandler {
+	cfg := &Config{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+	if cfg.Manager == nil {
+		cfg.Manager = goapperrors.Default()
+	}
+	if cfg.Renderers == nil {
+		cfg.Renderers = DefaultRenderers()
+	}
+	return &ErrorHandler{cfg: cfg}
+}
+
+// Handler adapts the handler function to http.Handler.
+// Errors returned by the function are written via WriteError, panics are recovered as errors.
+//
+// Example:
+//
+//	mux.Handle("/projects", errHandler.Handler(func(w http.ResponseWriter, r *http.Request) error {
+//		project, err := findProject(r)
+//		if err != nil {
+//			return err
+//		}
+//		return json.NewEncoder(w).Encode(project)
+//	}))
+func (h *ErrorHandler) Handler(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer h.recover(w, r)
+		if err := fn(w, r); err != nil {
+			h.WriteError(w, r, err)
+		}
+	})
+}
+
+// Middleware recovers panics of the next handler and writes them as errors
+func (h *ErrorHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer h.recover(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteError builds error info in the language of the request, calls the logging hook if
+// the log level is not `LogLevelNone`, then writes the error info in the format negotiated
+// from the request header Accept. If the selected renderer fails, JSON is written instead.
+// Headers of the error (see ErrorConfig.Headers) are set to the response.
+func (h *ErrorHandler) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	result := h.cfg.Manager.Build(err, h.language(r), h.cfg.BuildOptions...)
+	if h.cfg.LogFunc != nil && result.ErrorInfo.LogLevel != goapperrors.LogLevelNone {
+		h.cfg.LogFunc(r, err, result)
+	}
+
+	status := result.ErrorInfo.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	_, renderer := h.cfg.Renderers.Negotiate(r.Header.Get("Accept"))
+	var body bytes.Buffer
+	if renderErr := renderer.Render(&body, r, result.ErrorInfo); renderErr != nil {
+		renderer = JSONRenderer()
+		body.Reset()
+		_ = renderer.Render(&body, r, result.ErrorInfo)
+	}
+	for k, values := range result.ErrorInfo.Headers {
+		w.Header()[k] = values
+	}
+	w.Header().Set("Content-Type", renderer.ContentType())
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+	_, _ = w.Write(body.Bytes())
+}
+
+// recover recovers from panic and writes it as an error.
+// http.ErrAbortHandler is re-panicked to abort the response as expected by net/http.
+func (h *ErrorHandler) recover(w http.ResponseWriter, r *http.Request) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+	if rec == http.ErrAbortHandler { //nolint:errorlint,err113
+		panic(rec)
+	}
+	h.WriteError(w, r, h.panicError(rec))
+}
+
+// panicError creates an AppError for the recovered value, the stack trace of the panic is
+// captured in the error
+func (h *ErrorHandler) panicError(rec any) goapperrors.AppError {
+	cause, ok := rec.(error)
+	if !ok {
+		cause = fmt.Errorf("%v", rec) //nolint:err113
+	}
+	appErr := h.cfg.Manager.New(ErrPanic).WithCause(cause).WithDebug("panic: %v", rec)
+	if h.cfg.Manager.GetErrorConfig(ErrPanic) == nil {
+		_ = appErr.WithCustomConfig(&goapperrors.ErrorConfig{
+			Status:   http.StatusInternalServerError,
+			LogLevel: goapperrors.LogLevelError,
+		})
+	}
+	return appErr
+}
+
+// language returns the language of the request
+func (h *ErrorHandler) language(r *http.Request) goapperrors.Language {
+	if h.cfg.LanguageFunc != nil {
+		return h.cfg.LanguageFunc(r)
+	}
+	langs, _ := goapperrors.ParseAcceptLanguageAsStr(r.Header.Get("Accept-Language"))
+	for _, lang := range langs {
+		if lang != "mul" && lang != "und" {
+			return lang
+		}
+	}
+	return h.cfg.Manager.Config().DefaultLanguage
+}
@@ -0,0 +1,188 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+func testTranslate(lang goapperrors.Language, key string, params map[string]any) (string, error) {
+	return key + "-in-" + lang.(string), nil
+}
+
+type testLogEntry struct {
+	err   error
+	level goapperrors.LogLevel
+}
+
+func newTestHandler(t *testing.T, options ...Option) (*goapperrors.Manager, *ErrorHandler, *[]testLogEntry) {
+	t.Helper()
+	m := goapperrors.NewManager(&goapperrors.Config{
+		DefaultLanguage: "de",
+		TranslationFunc: testTranslate,
+	})
+	var logs []testLogEntry
+	options = append([]Option{
+		OptionManager(m),
+		OptionLogFunc(func(r *http.Request, err error, result *goapperrors.InfoBuilderResult) {
+			logs = append(logs, testLogEntry{err: err, level: result.ErrorInfo.LogLevel})
+		}),
+	}, options...)
+	return m, NewErrorHandler(options...), &logs
+}
+
+func Test_ErrorHandler_Handler(t *testing.T) {
+	t.Run("error returned", func(t *testing.T) {
+		m, h, logs := newTestHandler(t)
+		errNotFound := m.Create("ErrProjectNotFound", &goapperrors.ErrorConfig{
+			Status:   http.StatusNotFound,
+			LogLevel: goapperrors.LogLevelInfo,
+		})
+		handler := h.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			return m.New(errNotFound)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/1", nil)
+		req.Header.Set("Accept-Language", "fr-CH, fr;q=0.9")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"status": 404,
+			"code": "ErrProjectNotFound",
+			"title": "Not Found-in-fr-CH",
+			"message": "ErrProjectNotFound-in-fr-CH",
+			"logLevel": "info"
+		}`, rec.Body.String())
+		assert.Equal(t, 1, len(*logs))
+		assert.ErrorIs(t, (*logs)[0].err, errNotFound)
+		assert.Equal(t, goapperrors.LogLevelInfo, (*logs)[0].level)
+	})
+
+	t.Run("no error and default language", func(t *testing.T) {
+		_, h, logs := newTestHandler(t)
+		handler := h.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, 0, len(*logs))
+
+		assert.Equal(t, "de", h.language(httptest.NewRequest(http.MethodGet, "/", nil)))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "*")
+		assert.Equal(t, "de", h.language(req))
+	})
+
+	t.Run("log level none is not logged", func(t *testing.T) {
+		_, h, logs := newTestHandler(t, OptionLanguageFunc(func(*http.Request) goapperrors.Language { return "es" }))
+		handler := h.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("ErrUnexpected")
+		})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"message":"ErrUnexpected-in-es"`)
+		assert.Equal(t, 0, len(*logs))
+	})
+
+	t.Run("language matcher", func(t *testing.T) {
+		_, h, _ := newTestHandler(t, OptionLanguageMatcher(goapperrors.NewLanguageMatcher("de", "fr", "en")))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "fr-CH, en;q=0.9")
+		assert.Equal(t, "fr", h.language(req))
+		req.Header.Set("Accept-Language", "ja")
+		assert.Equal(t, "de", h.language(req))
+	})
+
+	t.Run("panic recovered", func(t *testing.T) {
+		_, h, logs := newTestHandler(t)
+		handler := h.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			panic("something wrong")
+		})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "ErrPanic", body["code"])
+		assert.Equal(t, 1, len(*logs))
+		assert.Equal(t, goapperrors.LogLevelError, (*logs)[0].level)
+
+		panicErr := (*logs)[0].err
+		assert.ErrorIs(t, panicErr, ErrPanic)
+		assert.Equal(t, "something wrong", panicErr.(goapperrors.AppError).Cause().Error())
+		hasHandlerFrame := false
+		for _, frame := range goapperrors.GetStackTrace(panicErr) {
+			if strings.Contains(frame.Function, "Test_ErrorHandler_Handler") {
+				hasHandlerFrame = true
+			}
+		}
+		assert.True(t, hasHandlerFrame)
+	})
+}
+
+func Test_ErrorHandler_Middleware(t *testing.T) {
+	t.Run("panic with error value and custom mapping", func(t *testing.T) {
+		m, h, _ := newTestHandler(t)
+		_ = m.Add(ErrPanic, &goapperrors.ErrorConfig{Status: http.StatusServiceUnavailable})
+		errBoom := errors.New("boom")
+		handler := h.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(errBoom)
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		_, h, _ := newTestHandler(t)
+		handler := h.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ok", rec.Body.String())
+	})
+
+	t.Run("abort handler is re-panicked", func(t *testing.T) {
+		_, h, _ := newTestHandler(t)
+		handler := h.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func Test_ErrorHandler_WriteError(t *testing.T) {
+	t.Run("error headers", func(t *testing.T) {
+		m, h, _ := newTestHandler(t)
+		errRateLimited := m.Create("ErrRateLimited", &goapperrors.ErrorConfig{
+			Status:  http.StatusTooManyRequests,
+			Headers: map[string]string{"Retry-After": "{retryAfter}"},
+		})
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", MediaTypeText)
+		h.WriteError(rec, req, m.New(errRateLimited).WithParam("retryAfter", 120))
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "120", rec.Header().Get("Retry-After"))
+		assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+}
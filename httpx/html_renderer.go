@@ -0,0 +1,127 @@
+package httpx
+
+import (
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"runtime"
+	"strconv"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// MediaTypeHTML media type of HTML pages
+const MediaTypeHTML = "text/html"
+
+// defaultHTMLTemplate template used when no template in the set matches an error
+var defaultHTMLTemplate = template.Must(template.New("default").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Message}}</p>
+{{- if .InnerErrors}}
+<ul>
+{{- range .InnerErrors}}
+<li>{{.Message}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .DebugMode}}
+<pre>{{.Code}}{{if .Cause}}: {{.Cause}}{{end}}{{if .Debug}}
+{{.Debug}}{{end}}
+{{- range .Stack}}
+{{.Function}}
+	{{.File}}:{{.Line}}
+{{- end}}</pre>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTMLPageData data passed to HTML templates
+type HTMLPageData struct {
+	*goapperrors.ErrorInfo
+	// DebugMode is `true` if the manager is in debug mode.
+	// Otherwise fields `Cause`, `Debug` and `Stack` are always empty.
+	DebugMode bool
+	// Stack stack trace of the error if available
+	Stack []runtime.Frame
+}
+
+// HTMLRenderer renders error info as HTML pages using templates.
+// For every error, the template is looked up by the error code, then the status, then `default`,
+// with or without the extension `.html` (e.g. `ErrUserNotFound.html`, `404.html`, `default.html`).
+// If no template is found, a built-in page is rendered. Values are escaped by html/template.
+type HTMLRenderer struct {
+	templates *template.Template
+	manager   *goapperrors.Manager
+}
+
+// NewHTMLRenderer creates an HTMLRenderer with the template set.
+// Debug mode is read from the config of the manager (default: the global one).
+func NewHTMLRenderer(templates *template.Template, manager *goapperrors.Manager) *HTMLRenderer {
+	if manager == nil {
+		manager = goapperrors.Default()
+	}
+	return &HTMLRenderer{templates: templates, manager: manager}
+}
+
+// NewHTMLRendererFS creates an HTMLRenderer with templates parsed from the file system (e.g. embed.FS).
+//
+// Example:
+//
+//	//go:embed templates/errors/*.html
+//	var templateFS embed.FS
+//
+//	renderer, err := NewHTMLRendererFS(nil, templateFS, "templates/errors/*.html")
+func NewHTMLRendererFS(manager *goapperrors.Manager, fsys fs.FS, patterns ...string) (*HTMLRenderer, error) {
+	templates, err := template.ParseFS(fsys, patterns...)
+	if err != nil {
+		return nil, goapperrors.Wrap(err)
+	}
+	return NewHTMLRenderer(templates, manager), nil
+}
+
+// ContentType implements Renderer
+func (hr *HTMLRenderer) ContentType() string {
+	return MediaTypeHTML + "; charset=utf-8"
+}
+
+// Render implements Renderer
+func (hr *HTMLRenderer) Render(w io.Writer, _ *http.Request, errInfo *goapperrors.ErrorInfo) error {
+	data := &HTMLPageData{
+		ErrorInfo: errInfo,
+		DebugMode: hr.manager.Config().Debug,
+	}
+	if data.DebugMode {
+		data.Stack = goapperrors.GetStackTrace(errInfo.AssociatedError)
+	} else if errInfo.Cause != "" || errInfo.Debug != "" {
+		// Custom info builders may set these fields regardless of debug mode
+		errInfoCopy := *errInfo
+		errInfoCopy.Cause, errInfoCopy.Debug = "", ""
+		data.ErrorInfo = &errInfoCopy
+	}
+	return hr.lookup(errInfo).Execute(w, data) //nolint:wrapcheck
+}
+
+// lookup finds the template for the error info
+func (hr *HTMLRenderer) lookup(errInfo *goapperrors.ErrorInfo) *template.Template {
+	if hr.templates == nil {
+		return defaultHTMLTemplate
+	}
+	names := []string{errInfo.Code, strconv.Itoa(errInfo.Status), "default"}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if tmpl := hr.templates.Lookup(name); tmpl != nil {
+			return tmpl
+		}
+		if tmpl := hr.templates.Lookup(name + ".html"); tmpl != nil {
+			return tmpl
+		}
+	}
+	return defaultHTMLTemplate
+}
@@ -0,0 +1,90 @@
+package httpx
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+//go:embed testdata/templates/*.html
+var testTemplateFS embed.FS
+
+var errTestHTML = errors.New("ErrTestHTML")
+
+func Test_HTMLRenderer(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	render := func(hr *HTMLRenderer, errInfo *goapperrors.ErrorInfo) string {
+		var buf bytes.Buffer
+		assert.NoError(t, hr.Render(&buf, req, errInfo))
+		return buf.String()
+	}
+
+	t.Run("templates by code, status and default", func(t *testing.T) {
+		m := goapperrors.NewManager(&goapperrors.Config{Debug: true})
+		hr, err := NewHTMLRendererFS(m, testTemplateFS, "testdata/templates/*.html")
+		assert.NoError(t, err)
+		assert.Equal(t, "text/html; charset=utf-8", hr.ContentType())
+
+		assert.Equal(t, "<h1>Forbidden for &lt;script&gt;</h1>\n", render(hr, &goapperrors.ErrorInfo{
+			Status: 403, Code: "ErrForbidden", Params: map[string]any{"user": "<script>"},
+		}))
+		assert.Equal(t, "<h1>Not found: a &amp; b</h1><pre>cause</pre>\n", render(hr, &goapperrors.ErrorInfo{
+			Status: 404, Code: "ErrProjectNotFound", Message: "a & b", Cause: "cause",
+		}))
+		assert.Equal(t, "<h1>500 Internal Server Error</h1>\n", render(hr, &goapperrors.ErrorInfo{
+			Status: 500, Code: "ErrInternal", Title: "Internal Server Error",
+		}))
+	})
+
+	t.Run("debug info is hidden in non-debug mode", func(t *testing.T) {
+		m := goapperrors.NewManager(&goapperrors.Config{})
+		hr, err := NewHTMLRendererFS(m, testTemplateFS, "testdata/templates/*.html")
+		assert.NoError(t, err)
+		errInfo := &goapperrors.ErrorInfo{Status: 404, Message: "missing", Cause: "secret"}
+		assert.Equal(t, "<h1>Not found: missing</h1>\n", render(hr, errInfo))
+		assert.Equal(t, "secret", errInfo.Cause) // the input is not modified
+	})
+
+	t.Run("built-in page", func(t *testing.T) {
+		mDebug := goapperrors.NewManager(&goapperrors.Config{Debug: true})
+		appErr := mDebug.New(goapperrors.Wrap(errTestHTML))
+		errInfo := mDebug.Build(appErr, goapperrors.LanguageEn).ErrorInfo
+		errInfo.InnerErrors = []*goapperrors.ErrorInfo{{Message: "<inner>"}}
+
+		page := render(NewHTMLRenderer(nil, mDebug), errInfo)
+		assert.Contains(t, page, "<title>Internal Server Error</title>")
+		assert.Contains(t, page, "<li>&lt;inner&gt;</li>")
+		assert.Contains(t, page, "Test_HTMLRenderer") // stack trace
+
+		page = render(NewHTMLRenderer(template.New("empty"), goapperrors.NewManager(&goapperrors.Config{})), errInfo)
+		assert.Contains(t, page, "<h1>Internal Server Error</h1>")
+		assert.NotContains(t, page, "<pre>")
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		_, err := NewHTMLRendererFS(nil, testTemplateFS, "testdata/none/*.html")
+		assert.Error(t, err)
+	})
+}
+
+func Test_HTMLRenderer_Negotiation(t *testing.T) {
+	renderers := DefaultRenderers()
+	renderers.Register(MediaTypeHTML, NewHTMLRenderer(nil, nil))
+	_, h, _ := newTestHandler(t, OptionRenderers(renderers))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	rec := httptest.NewRecorder()
+	h.WriteError(rec, req, errTestHTML)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<!DOCTYPE html>")
+}
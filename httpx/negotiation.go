@@ -0,0 +1,74 @@
+package httpx
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// mediaRange a media range with its quality value in header Accept
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept parses header Accept to a list of media ranges.
+// Invalid ranges are skipped, ranges without `q` parameter have the quality of 1.
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			continue
+		}
+		quality := 1.0
+		if q, exists := params["q"]; exists {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// matchSpecificity returns how specific the media range matches the media type:
+// 3 for exact match, 2 for `type/*`, 1 for `*/*`, and 0 for no match
+func matchSpecificity(mediaRange, mediaType string) int {
+	if mediaRange == mediaType {
+		return 3 //nolint:mnd
+	}
+	if mediaRange == "*/*" {
+		return 1
+	}
+	rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+	typ, _, _ := strings.Cut(mediaType, "/")
+	if rangeSubtype == "*" && rangeType == typ {
+		return 2 //nolint:mnd
+	}
+	return 0
+}
+
+// negotiate selects the media type preferred by the header Accept among the given ones.
+// The quality of a media type is taken from the most specific matching range. Among the media types
+// having the same quality, the one matched more specifically wins, then the one coming first.
+// Returns an empty string if no media type is acceptable.
+func negotiate(accept string, mediaTypes []string) string {
+	ranges := parseAccept(accept)
+	best, bestQuality, bestSpecificity := "", 0.0, 0
+	for _, mediaType := range mediaTypes {
+		quality, specificity := 0.0, 0
+		for _, r := range ranges {
+			if s := matchSpecificity(r.mediaType, mediaType); s > specificity {
+				quality, specificity = r.quality, s
+			}
+		}
+		if quality > bestQuality || (quality == bestQuality && quality > 0 && specificity > bestSpecificity) {
+			best, bestQuality, bestSpecificity = mediaType, quality, specificity
+		}
+	}
+	return best
+}
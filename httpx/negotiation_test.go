@@ -0,0 +1,39 @@
+package httpx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseAccept(t *testing.T) {
+	assert.Equal(t, []mediaRange{
+		{mediaType: "text/html", quality: 1},
+		{mediaType: "application/xml", quality: 0.9},
+		{mediaType: "*/*", quality: 0.8},
+	}, parseAccept("text/html, application/xml;q=0.9, invalid, ,*/*;q=0.8, text/plain;q=2"))
+	assert.Nil(t, parseAccept(""))
+}
+
+func Test_negotiate(t *testing.T) {
+	mediaTypes := []string{"application/json", "application/problem+json", "application/xml", "text/plain"}
+
+	testCases := []struct {
+		accept   string
+		expected string
+	}{
+		{accept: "application/xml", expected: "application/xml"},
+		{accept: "*/*", expected: "application/json"},
+		{accept: "text/*", expected: "text/plain"},
+		{accept: "application/json;q=0.5, application/problem+json", expected: "application/problem+json"},
+		{accept: "application/*;q=0.5, application/xml", expected: "application/xml"},
+		{accept: "*/*;q=0.1, application/json;q=0", expected: "application/problem+json"},
+		{accept: "application/*, */*", expected: "application/json"},
+		{accept: "*/*, text/plain", expected: "text/plain"},
+		{accept: "image/png", expected: ""},
+		{accept: "application/json;q=0", expected: ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, negotiate(tc.accept, mediaTypes), tc.accept)
+	}
+}
@@ -0,0 +1,241 @@
+package httpx
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// Media types of the built-in renderers
+const (
+	MediaTypeJSON        = "application/json"
+	MediaTypeProblemJSON = goapperrors.ProblemDetailsMediaType
+	MediaTypeXML         = "application/xml"
+	MediaTypeText        = "text/plain"
+)
+
+const defaultRendererCapacity = 4
+
+// Renderer renders error info in a specific format
+type Renderer interface {
+	// ContentType returns value of the response header Content-Type
+	ContentType() string
+	// Render writes the error info to the response body
+	Render(w io.Writer, r *http.Request, errInfo *goapperrors.ErrorInfo) error
+}
+
+// RenderFunc function rendering error info
+type RenderFunc func(w io.Writer, r *http.Request, errInfo *goapperrors.ErrorInfo) error
+
+type funcRenderer struct {
+	contentType string
+	render      RenderFunc
+}
+
+func (fr *funcRenderer) ContentType() string {
+	return fr.contentType
+}
+
+func (fr *funcRenderer) Render(w io.Writer, r *http.Request, errInfo *goapperrors.ErrorInfo) error {
+	return fr.render(w, r, errInfo)
+}
+
+// NewRenderer creates a Renderer from the content type and the render function
+func NewRenderer(contentType string, render RenderFunc) Renderer {
+	return &funcRenderer{contentType: contentType, render: render}
+}
+
+// JSONRenderer renders error info as JSON
+func JSONRenderer() Renderer {
+	return NewRenderer(MediaTypeJSON+"; charset=utf-8",
+		func(w io.Writer, _ *http.Request, errInfo *goapperrors.ErrorInfo) error {
+			return json.NewEncoder(w).Encode(errInfo) //nolint:wrapcheck
+		})
+}
+
+// ProblemJSONRenderer renders error info as RFC 9457 problem details.
+// The member `instance` is set to the request path unless set via the options.
+func ProblemJSONRenderer(options ...goapperrors.ProblemDetailsOption) Renderer {
+	return NewRenderer(MediaTypeProblemJSON,
+		func(w io.Writer, r *http.Request, errInfo *goapperrors.ErrorInfo) error {
+			opts := append([]goapperrors.ProblemDetailsOption{
+				goapperrors.ProblemDetailsOptionInstance(r.URL.Path),
+			}, options...)
+			return json.NewEncoder(w).Encode(errInfo.ProblemDetails(opts...)) //nolint:wrapcheck
+		})
+}
+
+// xmlErrorInfo XML representation of error info
+type xmlErrorInfo struct {
+	XMLName     xml.Name        `xml:"error"`
+	Status      int             `xml:"status,omitempty"`
+	Code        string          `xml:"code,omitempty"`
+	Source      *xmlSource      `xml:"source,omitempty"`
+	Title       string          `xml:"title,omitempty"`
+	Message     string          `xml:"message,omitempty"`
+	Cause       string          `xml:"cause,omitempty"`
+	Debug       string          `xml:"debug,omitempty"`
+	InnerErrors *xmlInnerErrors `xml:"errors,omitempty"`
+}
+
+// xmlSource XML representation of error source, structured sources are rendered as attributes,
+// other values as text
+type xmlSource struct {
+	Pointer   string `xml:"pointer,attr,omitempty"`
+	Parameter string `xml:"parameter,attr,omitempty"`
+	Header    string `xml:"header,attr,omitempty"`
+	PathParam string `xml:"pathParam,attr,omitempty"`
+	Label     string `xml:"label,attr,omitempty"`
+	Value     string `xml:",chardata"`
+}
+
+func newXMLSource(source any) *xmlSource {
+	switch s := source.(type) {
+	case nil:
+		return nil
+	case *goapperrors.Source:
+		if s == nil {
+			return nil
+		}
+		return &xmlSource{Pointer: s.Pointer, Parameter: s.Parameter, Header: s.Header,
+			PathParam: s.PathParam, Label: s.Label}
+	case goapperrors.Source:
+		return newXMLSource(&s)
+	}
+	return &xmlSource{Value: fmt.Sprint(source)}
+}
+
+type xmlInnerErrors struct {
+	Errors []*xmlErrorInfo `xml:"error"`
+}
+
+func newXMLErrorInfo(errInfo *goapperrors.ErrorInfo) *xmlErrorInfo {
+	xmlInfo := &xmlErrorInfo{
+		Status:  errInfo.Status,
+		Code:    errInfo.Code,
+		Source:  newXMLSource(errInfo.Source),
+		Title:   errInfo.Title,
+		Message: errInfo.Message,
+		Cause:   errInfo.Cause,
+		Debug:   errInfo.Debug,
+	}
+	if len(errInfo.InnerErrors) > 0 {
+		xmlInfo.InnerErrors = &xmlInnerErrors{}
+		for _, inErr := range errInfo.InnerErrors {
+			xmlInfo.InnerErrors.Errors = append(xmlInfo.InnerErrors.Errors, newXMLErrorInfo(inErr))
+		}
+	}
+	return xmlInfo
+}
+
+// XMLRenderer renders error info as XML
+func XMLRenderer() Renderer {
+	return NewRenderer(MediaTypeXML+"; charset=utf-8",
+		func(w io.Writer, _ *http.Request, errInfo *goapperrors.ErrorInfo) error {
+			if _, err := io.WriteString(w, xml.Header); err != nil {
+				return err //nolint:wrapcheck
+			}
+			return xml.NewEncoder(w).Encode(newXMLErrorInfo(errInfo)) //nolint:wrapcheck
+		})
+}
+
+// TextRenderer renders error info as plain text, inner errors are rendered one per line
+func TextRenderer() Renderer {
+	return NewRenderer(MediaTypeText+"; charset=utf-8",
+		func(w io.Writer, _ *http.Request, errInfo *goapperrors.ErrorInfo) error {
+			var sb strings.Builder
+			writeTextErrorInfo(&sb, errInfo, "")
+			_, err := io.WriteString(w, sb.String())
+			return err //nolint:wrapcheck
+		})
+}
+
+func writeTextErrorInfo(sb *strings.Builder, errInfo *goapperrors.ErrorInfo, indent string) {
+	sb.WriteString(indent)
+	if errInfo.Title != "" {
+		sb.WriteString(errInfo.Title)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(errInfo.Message)
+	sb.WriteString("\n")
+	for _, inErr := range errInfo.InnerErrors {
+		writeTextErrorInfo(sb, inErr, indent+"  - ")
+	}
+}
+
+// Renderers registry of renderers keyed by media type.
+// The renderer is selected according to the request header Accept, or the default one is used.
+// A registry should be set up at startup, it is not safe to register renderers concurrently with
+// rendering errors.
+type Renderers struct {
+	renderers        map[string]Renderer
+	mediaTypes       []string
+	defaultMediaType string
+}
+
+// NewRenderers creates a registry with the default renderer for the media type
+func NewRenderers(defaultMediaType string, defaultRenderer Renderer) *Renderers {
+	reg := &Renderers{
+		renderers: make(map[string]Renderer, defaultRendererCapacity),
+	}
+	reg.Register(defaultMediaType, defaultRenderer)
+	reg.defaultMediaType = reg.mediaTypes[0]
+	return reg
+}
+
+// DefaultRenderers creates a registry of the built-in renderers, JSON is the default one
+func DefaultRenderers() *Renderers {
+	reg := NewRenderers(MediaTypeJSON, JSONRenderer())
+	reg.Register(MediaTypeProblemJSON, ProblemJSONRenderer())
+	reg.Register(MediaTypeXML, XMLRenderer())
+	reg.Register(MediaTypeText, TextRenderer())
+	return reg
+}
+
+// Register registers the renderer for the media type, the existing one is replaced.
+// When the request accepts several media types with the same preference, the one registered
+// first is selected. This function panics if the media type is invalid or the renderer is nil.
+func (reg *Renderers) Register(mediaType string, renderer Renderer) {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if typ, subtype, ok := strings.Cut(mediaType, "/"); !ok || typ == "" || subtype == "" ||
+		strings.Contains(mediaType, "*") {
+		panic(fmt.Sprintf("invalid media type %q", mediaType))
+	}
+	if renderer == nil {
+		panic("renderer must not be nil")
+	}
+	if _, exists := reg.renderers[mediaType]; !exists {
+		reg.mediaTypes = append(reg.mediaTypes, mediaType)
+	}
+	reg.renderers[mediaType] = renderer
+}
+
+// SetDefault sets the default media type, this function panics if it is not registered
+func (reg *Renderers) SetDefault(mediaType string) {
+	mediaType = strings.ToLower(mediaType)
+	if _, exists := reg.renderers[mediaType]; !exists {
+		panic(fmt.Sprintf("renderer for media type %q is not registered", mediaType))
+	}
+	reg.defaultMediaType = mediaType
+}
+
+// Get returns the renderer for the media type, returns `nil` if not found
+func (reg *Renderers) Get(mediaType string) Renderer {
+	return reg.renderers[strings.ToLower(mediaType)]
+}
+
+// Negotiate selects the renderer for the request header Accept.
+// The default renderer is selected if the header is empty or no registered media type is acceptable.
+func (reg *Renderers) Negotiate(accept string) (mediaType string, renderer Renderer) {
+	if strings.TrimSpace(accept) != "" {
+		if mediaType = negotiate(accept, reg.mediaTypes); mediaType != "" {
+			return mediaType, reg.renderers[mediaType]
+		}
+	}
+	return reg.defaultMediaType, reg.renderers[reg.defaultMediaType]
+}
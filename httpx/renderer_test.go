@@ -0,0 +1,121 @@
+package httpx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+func testErrorInfo() *goapperrors.ErrorInfo {
+	return &goapperrors.ErrorInfo{
+		Status:  http.StatusBadRequest,
+		Code:    "ErrValidation",
+		Title:   "Bad Request",
+		Message: "Invalid input",
+		InnerErrors: []*goapperrors.ErrorInfo{
+			{Code: "ErrRequired", Source: "name", Message: "Name is required"},
+		},
+	}
+}
+
+func Test_Renderers(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+
+	t.Run("built-in renderers", func(t *testing.T) {
+		reg := DefaultRenderers()
+		var buf bytes.Buffer
+
+		assert.NoError(t, reg.Get(MediaTypeJSON).Render(&buf, req, testErrorInfo()))
+		assert.JSONEq(t, `{"status": 400, "code": "ErrValidation", "title": "Bad Request",
+			"message": "Invalid input", "errors": [
+				{"code": "ErrRequired", "source": "name", "message": "Name is required"}
+			]}`, buf.String())
+
+		buf.Reset()
+		assert.NoError(t, reg.Get(MediaTypeProblemJSON).Render(&buf, req, testErrorInfo()))
+		assert.Contains(t, buf.String(), `"instance":"/projects"`)
+		assert.Equal(t, MediaTypeProblemJSON, reg.Get(MediaTypeProblemJSON).ContentType())
+
+		buf.Reset()
+		assert.NoError(t, reg.Get(MediaTypeXML).Render(&buf, req, testErrorInfo()))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<error><status>400</status><code>ErrValidation</code><title>Bad Request</title>`+
+			`<message>Invalid input</message><errors><error><code>ErrRequired</code><source>name</source>`+
+			`<message>Name is required</message></error></errors></error>`, buf.String())
+
+		buf.Reset()
+		assert.NoError(t, reg.Get(MediaTypeText).Render(&buf, req, testErrorInfo()))
+		assert.Equal(t, "Bad Request: Invalid input\n  - Name is required\n", buf.String())
+	})
+
+	t.Run("XML structured source", func(t *testing.T) {
+		var buf bytes.Buffer
+		errInfo := &goapperrors.ErrorInfo{
+			Code:   "ErrInvalidItem",
+			Source: goapperrors.SourcePointer("items[3].name").WithLabel("Name"),
+		}
+		assert.NoError(t, XMLRenderer().Render(&buf, req, errInfo))
+		assert.Contains(t, buf.String(), `<source pointer="/items/3/name" label="Name"></source>`)
+	})
+
+	t.Run("register and negotiate", func(t *testing.T) {
+		reg := DefaultRenderers()
+		mediaType, renderer := reg.Negotiate("")
+		assert.Equal(t, MediaTypeJSON, mediaType)
+		assert.Equal(t, MediaTypeJSON+"; charset=utf-8", renderer.ContentType())
+
+		reg.Register("Application/Vnd.API+json", NewRenderer("application/vnd.api+json",
+			func(w io.Writer, r *http.Request, errInfo *goapperrors.ErrorInfo) error { return nil }))
+		mediaType, _ = reg.Negotiate("application/vnd.api+json")
+		assert.Equal(t, "application/vnd.api+json", mediaType)
+
+		reg.SetDefault(MediaTypeProblemJSON)
+		mediaType, _ = reg.Negotiate("image/png")
+		assert.Equal(t, MediaTypeProblemJSON, mediaType)
+
+		assert.Panics(t, func() { reg.SetDefault("text/html") })
+		assert.Panics(t, func() { reg.Register("text/*", TextRenderer()) })
+		assert.Panics(t, func() { reg.Register("text", TextRenderer()) })
+		assert.Panics(t, func() { reg.Register("text/html", nil) })
+		assert.Nil(t, reg.Get("text/html"))
+	})
+}
+
+func Test_ErrorHandler_Renderers(t *testing.T) {
+	m, h, _ := newTestHandler(t)
+	errNotFound := m.Create("ErrNotFound", &goapperrors.ErrorConfig{Status: http.StatusNotFound})
+	handler := h.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return m.New(errNotFound)
+	})
+
+	t.Run("negotiated renderer", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set("Accept", "text/plain;q=0.5, application/problem+json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MediaTypeProblemJSON, rec.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+		assert.Contains(t, rec.Body.String(), `"instance":"/items/1"`)
+	})
+
+	t.Run("failed renderer falls back to JSON", func(t *testing.T) {
+		reg := NewRenderers(MediaTypeText, NewRenderer("text/plain",
+			func(w io.Writer, r *http.Request, errInfo *goapperrors.ErrorInfo) error {
+				return errors.New("render failed")
+			}))
+		_, h, _ := newTestHandler(t, OptionManager(m), OptionRenderers(reg))
+		rec := httptest.NewRecorder()
+		h.WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), errNotFound)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MediaTypeJSON+"; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"code":"ErrNotFound"`)
+	})
+}
@@ -0,0 +1,450 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+// ErrMessageInvalid is returned when a message is not a valid ICU MessageFormat pattern,
+// or it can't be formatted with the given params
+var ErrMessageInvalid = errors.New("message invalid")
+
+// maxPluralOperand plural operands are passed modulo this value to plural rules
+const maxPluralOperand = 10_000_000
+
+// Message ICU MessageFormat pattern. Supported arguments are:
+//   - simple arguments: `{name}`, arguments of other types like `{name, number}` are formatted
+//     as simple ones
+//   - `{name, plural, [offset:N] =0 {...} one {...} other {...}}` with CLDR plural rules
+//   - `{name, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}`
+//   - `{name, select, male {...} female {...} other {...}}`
+//
+// `#` in plural branches is replaced by the number (minus the offset), a goapperrors.FormattedParam
+// is printed as its text while its raw value selects the branch. Arguments can be nested
+// in branches. Apostrophes quote special characters, e.g. `'{'`, and a doubled apostrophe
+// is an apostrophe.
+// Unknown simple arguments are kept as is.
+type Message struct {
+	nodes []messageNode
+}
+
+// messageNode a part of a message: a text, an argument, or `#` in a plural branch
+type messageNode struct {
+	text    string
+	arg     string
+	argType string
+	// offset offset of plural arguments
+	offset float64
+	// branches branches of complex arguments by selectors
+	branches map[string]*Message
+	// number `#` in plural branches
+	number bool
+}
+
+// ParseMessage parses an ICU MessageFormat pattern
+func ParseMessage(pattern string) (*Message, error) {
+	p := &messageParser{src: pattern}
+	msg, err := p.parseMessage(false)
+	if err != nil {
+		return nil, goapperrors.Wrapf("%w: %q at %d: %w", ErrMessageInvalid, pattern, p.pos, err)
+	}
+	if !p.eof() {
+		return nil, goapperrors.Wrapf("%w: %q at %d: unexpected '}'", ErrMessageInvalid, pattern, p.pos)
+	}
+	return msg, nil
+}
+
+// Format formats the message with the params, plural rules of the language are used to select
+// plural branches
+func (m *Message) Format(lang language.Tag, params map[string]any) (string, error) {
+	var sb strings.Builder
+	if err := m.format(&sb, lang, params, nil); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func (m *Message) format(sb *strings.Builder, lang language.Tag, params map[string]any, number *pluralNumber) error {
+	for i := range m.nodes {
+		node := &m.nodes[i]
+		switch {
+		case node.number:
+			if number != nil {
+				sb.WriteString(number.String())
+			} else {
+				sb.WriteString("#")
+			}
+		case node.arg == "":
+			sb.WriteString(node.text)
+		case node.branches == nil:
+			if v, exists := params[node.arg]; exists {
+				fmt.Fprint(sb, v)
+			} else {
+				sb.WriteString(node.text)
+			}
+		default:
+			if err := node.formatComplex(sb, lang, params, number); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// formatComplex formats a plural, selectordinal or select argument
+func (node *messageNode) formatComplex(sb *strings.Builder, lang language.Tag, params map[string]any,
+	number *pluralNumber) error {
+	v, exists := params[node.arg]
+	if !exists {
+		return goapperrors.Wrapf("%w: param %q is missing", ErrMessageInvalid, node.arg)
+	}
+	// Branches are selected by the raw values of formatted params
+	formatted, isFormatted := v.(goapperrors.FormattedParam)
+	if isFormatted {
+		v = formatted.Value
+	}
+	if node.argType == "select" {
+		branch := node.branches[fmt.Sprint(v)]
+		if branch == nil {
+			branch = node.branches["other"]
+		}
+		return branch.format(sb, lang, params, number)
+	}
+
+	n, err := newPluralNumber(v, node.offset)
+	if err != nil {
+		return goapperrors.Wrapf("%w: param %q: %w", ErrMessageInvalid, node.arg, err)
+	}
+	if isFormatted && node.offset == 0 {
+		n.text = formatted.Text
+	}
+	branch := node.branches["="+strconv.FormatFloat(n.value, 'f', -1, 64)]
+	if branch == nil {
+		rules := plural.Cardinal
+		if node.argType == "selectordinal" {
+			rules = plural.Ordinal
+		}
+		branch = node.branches[pluralFormName(n.match(lang, rules))]
+	}
+	if branch == nil {
+		branch = node.branches["other"]
+	}
+	return branch.format(sb, lang, params, n)
+}
+
+// pluralNumber number of a plural argument
+type pluralNumber struct {
+	// value value of the param
+	value float64
+	// digits decimal representation of the value minus the offset
+	digits string
+	// text localized text of the number if the param is formatted
+	text string
+}
+
+func newPluralNumber(v any, offset float64) (*pluralNumber, error) {
+	var value float64
+	var digits string
+	switch n := v.(type) {
+	case int:
+		value = float64(n)
+	case int8:
+		value = float64(n)
+	case int16:
+		value = float64(n)
+	case int32:
+		value = float64(n)
+	case int64:
+		value = float64(n)
+	case uint:
+		value = float64(n)
+	case uint8:
+		value = float64(n)
+	case uint16:
+		value = float64(n)
+	case uint32:
+		value = float64(n)
+	case uint64:
+		value = float64(n)
+	case float32:
+		value = float64(n)
+	case float64:
+		value = n
+	case string:
+		// Strings keep the visible fraction digits, e.g. `1.50`
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", n)
+		}
+		value = f
+		if offset == 0 {
+			digits = strings.TrimPrefix(strings.TrimSpace(n), "+")
+		}
+	default:
+		return nil, fmt.Errorf("%v is not a number", v)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%v is not a finite number", v)
+	}
+	if digits == "" {
+		digits = strconv.FormatFloat(value-offset, 'f', -1, 64)
+	}
+	return &pluralNumber{value: value, digits: digits}, nil
+}
+
+// String returns the number to replace `#`
+func (n *pluralNumber) String() string {
+	if n.text != "" {
+		return n.text
+	}
+	return n.digits
+}
+
+// match returns the plural form of the number by the rules
+func (n *pluralNumber) match(lang language.Tag, rules *plural.Rules) plural.Form {
+	intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(n.digits, "-"), ".")
+	trimmedFrac := strings.TrimRight(fracPart, "0")
+	return rules.MatchPlural(lang, operand(intPart), len(fracPart), len(trimmedFrac),
+		operand(fracPart), operand(trimmedFrac))
+}
+
+// operand converts digits to a plural operand modulo maxPluralOperand
+func operand(digits string) int {
+	if len(digits) > 7 { //nolint:mnd
+		digits = digits[len(digits)-7:]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n % maxPluralOperand
+}
+
+func pluralFormName(form plural.Form) string {
+	switch form {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+// messageParser parses ICU MessageFormat patterns
+type messageParser struct {
+	src string
+	pos int
+}
+
+func (p *messageParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *messageParser) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// parseMessage parses a message until the end or an unmatched `}`.
+// `#` is parsed as the number if the message is a plural branch.
+func (p *messageParser) parseMessage(inPlural bool) (*Message, error) {
+	msg := &Message{}
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			msg.nodes = append(msg.nodes, messageNode{text: text.String()})
+			text.Reset()
+		}
+	}
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch {
+		case c == '}':
+			flushText()
+			return msg, nil
+		case c == '{':
+			flushText()
+			node, err := p.parseArgument(inPlural)
+			if err != nil {
+				return nil, err
+			}
+			msg.nodes = append(msg.nodes, *node)
+		case c == '#' && inPlural:
+			flushText()
+			msg.nodes = append(msg.nodes, messageNode{number: true})
+			p.pos++
+		case c == '\'':
+			p.parseQuoted(&text, inPlural)
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	flushText()
+	return msg, nil
+}
+
+// parseQuoted parses text starting with an apostrophe: a doubled apostrophe is an apostrophe, and an apostrophe
+// followed by a special character starts a quoted text until the next single apostrophe
+func (p *messageParser) parseQuoted(text *strings.Builder, inPlural bool) {
+	p.pos++ // '
+	if p.eof() {
+		text.WriteByte('\'')
+		return
+	}
+	next := p.src[p.pos]
+	if next == '\'' {
+		text.WriteByte('\'')
+		p.pos++
+		return
+	}
+	if next != '{' && next != '}' && (next != '#' || !inPlural) {
+		text.WriteByte('\'')
+		return
+	}
+	for !p.eof() {
+		c := p.src[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteByte(c)
+			continue
+		}
+		if !p.eof() && p.src[p.pos] == '\'' {
+			text.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+// parseArgument parses an argument starting with `{`
+func (p *messageParser) parseArgument(inPlural bool) (*messageNode, error) {
+	start := p.pos
+	p.pos++ // {
+	p.skipSpace()
+	name := p.parseWord()
+	if name == "" {
+		return nil, errors.New("argument name expected")
+	}
+	node := &messageNode{arg: name}
+	p.skipSpace()
+	if p.eof() {
+		return nil, errors.New("unclosed argument")
+	}
+	if p.src[p.pos] == '}' {
+		p.pos++
+		node.text = p.src[start:p.pos]
+		return node, nil
+	}
+	if p.src[p.pos] != ',' {
+		return nil, fmt.Errorf("unexpected %q in argument", p.src[p.pos])
+	}
+	p.pos++
+	p.skipSpace()
+	node.argType = p.parseWord()
+	p.skipSpace()
+
+	switch node.argType {
+	case "plural", "selectordinal", "select":
+	case "":
+		return nil, errors.New("argument type expected")
+	default:
+		// Other types are formatted as simple arguments, the style is ignored
+		end := strings.IndexByte(p.src[p.pos:], '}')
+		if end < 0 {
+			return nil, errors.New("unclosed argument")
+		}
+		p.pos += end + 1
+		node.text = p.src[start:p.pos]
+		node.argType = ""
+		return node, nil
+	}
+
+	if p.eof() || p.src[p.pos] != ',' {
+		return nil, fmt.Errorf("branches of %s argument expected", node.argType)
+	}
+	p.pos++
+	if err := p.parseBranches(node, inPlural); err != nil {
+		return nil, err
+	}
+	node.text = p.src[start:p.pos]
+	return node, nil
+}
+
+// parseBranches parses the branches of a complex argument until the closing `}`.
+// `#` in branches of select arguments nested in plural branches refers to the outer number.
+func (p *messageParser) parseBranches(node *messageNode, inPlural bool) error {
+	node.branches = map[string]*Message{}
+	isPlural := node.argType != "select"
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return errors.New("unclosed argument")
+		}
+		if p.src[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		selector := p.parseWord()
+		if selector == "" {
+			return fmt.Errorf("selector expected, got %q", p.src[p.pos])
+		}
+		if isPlural && strings.HasPrefix(selector, "offset:") {
+			offset, err := strconv.ParseFloat(strings.TrimPrefix(selector, "offset:"), 64)
+			if err != nil || len(node.branches) > 0 {
+				return fmt.Errorf("invalid offset %q", selector)
+			}
+			node.offset = offset
+			continue
+		}
+		if strings.HasPrefix(selector, "=") {
+			if _, err := strconv.ParseFloat(selector[1:], 64); err != nil || !isPlural {
+				return fmt.Errorf("invalid selector %q", selector)
+			}
+		}
+		if _, exists := node.branches[selector]; exists {
+			return fmt.Errorf("duplicate selector %q", selector)
+		}
+		p.skipSpace()
+		if p.eof() || p.src[p.pos] != '{' {
+			return fmt.Errorf("branch of selector %q expected", selector)
+		}
+		p.pos++
+		branch, err := p.parseMessage(isPlural || inPlural)
+		if err != nil {
+			return err
+		}
+		if p.eof() {
+			return fmt.Errorf("unclosed branch of selector %q", selector)
+		}
+		p.pos++ // }
+		node.branches[selector] = branch
+	}
+	if node.branches["other"] == nil {
+		return fmt.Errorf("%s argument requires the branch `other`", node.argType)
+	}
+	return nil
+}
+
+// parseWord parses a name, a type or a selector
+func (p *messageParser) parseWord() string {
+	start := p.pos
+	for !p.eof() && strings.IndexByte(" \t\r\n{},", p.src[p.pos]) < 0 {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
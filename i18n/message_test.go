@@ -0,0 +1,180 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+func formatMessage(t *testing.T, pattern string, lang language.Tag, params map[string]any) string {
+	t.Helper()
+	msg, err := ParseMessage(pattern)
+	assert.NoError(t, err)
+	if err != nil {
+		return ""
+	}
+	s, err := msg.Format(lang, params)
+	assert.NoError(t, err)
+	return s
+}
+
+func Test_Message_Format(t *testing.T) {
+	t.Run("simple arguments", func(t *testing.T) {
+		assert.Equal(t, "User u1 has 3.5 GB, {unknown}",
+			formatMessage(t, "User {user} has { size, number } GB, {unknown}", language.English,
+				map[string]any{"user": "u1", "size": 3.5}))
+		assert.Equal(t, "No arguments", formatMessage(t, "No arguments", language.English, nil))
+		assert.Equal(t, "", formatMessage(t, "", language.English, nil))
+	})
+
+	t.Run("plural english", func(t *testing.T) {
+		pattern := "You have {count, plural, =0 {no invalid fields} one {# invalid field} other {# invalid fields}}"
+		for count, expected := range map[any]string{
+			0:       "You have no invalid fields",
+			1:       "You have 1 invalid field",
+			2:       "You have 2 invalid fields",
+			1.5:     "You have 1.5 invalid fields",
+			"1.0":   "You have 1.0 invalid fields",
+			"1":     "You have 1 invalid field",
+			int8(1): "You have 1 invalid field",
+			uint(7): "You have 7 invalid fields",
+		} {
+			assert.Equal(t, expected, formatMessage(t, pattern, language.English, map[string]any{"count": count}))
+		}
+	})
+
+	t.Run("plural russian", func(t *testing.T) {
+		pattern := "{count, plural, one {# поле} few {# поля} many {# полей} other {# поля}}"
+		for count, expected := range map[int]string{
+			1:  "1 поле",
+			2:  "2 поля",
+			5:  "5 полей",
+			11: "11 полей",
+			21: "21 поле",
+			22: "22 поля",
+		} {
+			assert.Equal(t, expected, formatMessage(t, pattern, language.Russian, map[string]any{"count": count}))
+		}
+		assert.Equal(t, "1.5 поля", formatMessage(t, pattern, language.Russian, map[string]any{"count": 1.5}))
+	})
+
+	t.Run("plural arabic", func(t *testing.T) {
+		pattern := "{n, plural, zero {zero} one {one} two {two} few {few} many {many} other {other}}"
+		for n, expected := range map[int]string{
+			0:   "zero",
+			1:   "one",
+			2:   "two",
+			3:   "few",
+			11:  "many",
+			100: "other",
+		} {
+			assert.Equal(t, expected, formatMessage(t, pattern, language.Arabic, map[string]any{"n": n}))
+		}
+	})
+
+	t.Run("selectordinal", func(t *testing.T) {
+		pattern := "{pos, selectordinal, one {#st} two {#nd} few {#rd} other {#th}} attempt"
+		for pos, expected := range map[int]string{
+			1:  "1st attempt",
+			2:  "2nd attempt",
+			3:  "3rd attempt",
+			4:  "4th attempt",
+			11: "11th attempt",
+			22: "22nd attempt",
+		} {
+			assert.Equal(t, expected, formatMessage(t, pattern, language.English, map[string]any{"pos": pos}))
+		}
+	})
+
+	t.Run("select and nested arguments", func(t *testing.T) {
+		pattern := "{gender, select, female {{name} updated her {count, plural, one {file} other {# files}}} " +
+			"other {{name} updated their {count, plural, one {file} other {# files}}}}"
+		assert.Equal(t, "Ann updated her 3 files", formatMessage(t, pattern, language.English,
+			map[string]any{"gender": "female", "name": "Ann", "count": 3}))
+		assert.Equal(t, "Sam updated their file", formatMessage(t, pattern, language.English,
+			map[string]any{"gender": "unknown", "name": "Sam", "count": 1}))
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		pattern := "{count, plural, offset:1 =0 {Nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}"
+		for count, expected := range map[int]string{
+			0: "Nobody",
+			1: "Ann",
+			2: "Ann and 1 other",
+			5: "Ann and 4 others",
+		} {
+			assert.Equal(t, expected, formatMessage(t, pattern, language.English,
+				map[string]any{"count": count, "host": "Ann"}))
+		}
+	})
+
+	t.Run("number in select nested in plural", func(t *testing.T) {
+		pattern := "{n, plural, other {{kind, select, file {# files} other {# items}}}} and #"
+		assert.Equal(t, "4 files and #", formatMessage(t, pattern, language.English,
+			map[string]any{"n": 4, "kind": "file"}))
+	})
+
+	t.Run("quoting", func(t *testing.T) {
+		assert.Equal(t, "Use {name} or '{x}', it's #1",
+			formatMessage(t, "Use '{name}' or '''{x}''', it''s #1", language.English, map[string]any{"name": "a"}))
+		assert.Equal(t, "1 #tag",
+			formatMessage(t, "{n, plural, other {# '#'tag}}", language.English, map[string]any{"n": 1}))
+		assert.Equal(t, "It's '", formatMessage(t, "It's '", language.English, nil))
+	})
+
+	t.Run("formatted params", func(t *testing.T) {
+		pattern := "{count, plural, one {# Anfrage} other {# Anfragen}} über {limit}, " +
+			"{kind, select, a {A} other {B}}"
+		assert.Equal(t, "1.234 Anfragen über 2.000, A", formatMessage(t, pattern, language.German, map[string]any{
+			"count": goapperrors.FormattedParam{Value: 1234, Text: "1.234"},
+			"limit": goapperrors.FormattedParam{Value: 2000, Text: "2.000"},
+			"kind":  goapperrors.FormattedParam{Value: "a", Text: "Ä"},
+		}))
+		assert.Equal(t, "1 Anfrage über 2, B", formatMessage(t, pattern, language.German, map[string]any{
+			"count": goapperrors.FormattedParam{Value: 1, Text: "1"},
+			"limit": 2,
+			"kind":  "b",
+		}))
+	})
+
+	t.Run("format errors", func(t *testing.T) {
+		msg, err := ParseMessage("{n, plural, one {one} other {other}}")
+		assert.NoError(t, err)
+		_, err = msg.Format(language.English, nil)
+		assert.ErrorIs(t, err, ErrMessageInvalid)
+		_, err = msg.Format(language.English, map[string]any{"n": "abc"})
+		assert.ErrorIs(t, err, ErrMessageInvalid)
+		_, err = msg.Format(language.English, map[string]any{"n": struct{}{}})
+		assert.ErrorIs(t, err, ErrMessageInvalid)
+	})
+}
+
+func Test_ParseMessage(t *testing.T) {
+	t.Run("invalid patterns", func(t *testing.T) {
+		for _, pattern := range []string{
+			"Unclosed {arg",
+			"Unexpected }",
+			"{}",
+			"{a b}",
+			"{a,}",
+			"{a, number",
+			"{n, plural}",
+			"{n, plural, one {x}}",
+			"{n, plural, one x other {y}}",
+			"{n, plural, one {x} one {y} other {z}}",
+			"{n, plural, =x {x} other {y}}",
+			"{n, plural, one {x} offset:1 other {y}}",
+			"{n, plural, offset:x other {y}}",
+			"{g, select, =1 {x} other {y}}",
+			"{g, select, other {x}",
+			"{g, select, other {x",
+			"{g, select, {x} other {y}}",
+		} {
+			_, err := ParseMessage(pattern)
+			assert.ErrorIs(t, err, ErrMessageInvalid, pattern)
+		}
+	})
+}
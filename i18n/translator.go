@@ -0,0 +1,346 @@
+// Package i18n provides a translator implementing goapperrors.TranslationFunc with messages
+// loaded from per-language bundle files.
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+var (
+	// ErrTranslationMissing is returned when a message is not found for a key in a language
+	ErrTranslationMissing = errors.New("translation missing")
+	// ErrBundleInvalid is returned when a message bundle is malformed
+	ErrBundleInvalid = errors.New("bundle invalid")
+	// ErrFormatUnsupported is returned when a bundle format is not supported
+	ErrFormatUnsupported = errors.New("bundle format unsupported")
+)
+
+// MissingKeyError error returned when a message is not found for a key in a language.
+// errors.Is(err, ErrTranslationMissing) returns `true` for this error.
+type MissingKeyError struct {
+	Language string
+	Key      string
+}
+
+// Error implements `error` interface
+func (e *MissingKeyError) Error() string {
+	return fmt.Sprintf("%s: key %q in language %q", ErrTranslationMissing.Error(), e.Key, e.Language)
+}
+
+// Is implementation used by errors.Is()
+func (e *MissingKeyError) Is(err error) bool {
+	return err == ErrTranslationMissing //nolint:errorlint
+}
+
+// Format format of a message bundle, it is also the file extension of the bundle files
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// UnmarshalFunc decodes a bundle into the map pointed to by `v`, e.g. json.Unmarshal
+type UnmarshalFunc func(data []byte, v any) error
+
+// builtinFormats formats supported by all translators
+var builtinFormats = map[Format]UnmarshalFunc{
+	FormatJSON: json.Unmarshal,
+	FormatYAML: yaml.Unmarshal,
+	FormatTOML: toml.Unmarshal,
+}
+
+// Translator translates messages using per-language bundles.
+// Nested tables in bundles are flattened with dots as separator, e.g. the message at `user` > `notFound`
+// has the key `user.notFound`. Messages are in ICU MessageFormat (see Message), e.g.
+// `{count, plural, one {# field is} other {# fields are}} invalid`, with params replacing the arguments.
+// JSON, YAML and TOML bundles are supported, other formats can be added with RegisterFormat.
+// Bundles should be loaded at startup, a translator is safe for concurrent use.
+//
+// Example:
+//
+//	//go:embed locales/*.yaml
+//	var localeFS embed.FS
+//
+//	translator := i18n.NewTranslator()
+//	if err := translator.LoadDir(localeFS, "locales"); err != nil { ... }
+//	goapperrors.Init(&goapperrors.Config{TranslationFunc: translator.Translate})
+type Translator struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]*bundleMessage
+	formats  map[Format]UnmarshalFunc
+}
+
+// bundleMessage a message with its parsed pattern
+type bundleMessage struct {
+	pattern string
+	message *Message
+	err     error
+}
+
+// NewTranslator creates an empty translator
+func NewTranslator() *Translator {
+	formats := make(map[Format]UnmarshalFunc, len(builtinFormats))
+	for format, unmarshal := range builtinFormats {
+		formats[format] = unmarshal
+	}
+	return &Translator{
+		messages: map[string]map[string]*bundleMessage{},
+		formats:  formats,
+	}
+}
+
+// RegisterFormat registers the unmarshal function of a bundle format, it replaces the existing one
+// of the format. Files with the format as extension are loaded by LoadFile and LoadDir.
+//
+// Example:
+//
+//	translator.RegisterFormat("json5", json5.Unmarshal) // github.com/yosuke-furukawa/json5/encoding/json5
+func (t *Translator) RegisterFormat(format Format, unmarshal UnmarshalFunc) {
+	if format == "" || unmarshal == nil {
+		panic("format and unmarshal function must not be empty")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.formats[Format(strings.ToLower(string(format)))] = unmarshal
+}
+
+// unmarshalerOf returns the unmarshal function of the format, `nil` if the format is not registered
+func (t *Translator) unmarshalerOf(format Format) UnmarshalFunc {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.formats[Format(strings.ToLower(string(format)))]
+}
+
+// AddMessages adds messages of the language, existing messages with the same keys are replaced.
+// Invalid messages are reported when they are translated.
+func (t *Translator) AddMessages(lang goapperrors.Language, messages map[string]string) {
+	if len(messages) == 0 {
+		return
+	}
+	parsed := make(map[string]*bundleMessage, len(messages))
+	for k, v := range messages {
+		msg, err := ParseMessage(v)
+		parsed[k] = &bundleMessage{pattern: v, message: msg, err: err}
+	}
+
+	langKey := languageKey(lang)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bundle := t.messages[langKey]
+	if bundle == nil {
+		bundle = make(map[string]*bundleMessage, len(messages))
+		t.messages[langKey] = bundle
+	}
+	for k, v := range parsed {
+		bundle[k] = v
+	}
+}
+
+// Load reads a bundle of the language in the given format
+func (t *Translator) Load(lang goapperrors.Language, r io.Reader, format Format) error {
+	unmarshal := t.unmarshalerOf(format)
+	if unmarshal == nil {
+		return goapperrors.Wrapf("%w: %s", ErrFormatUnsupported, format)
+	}
+	messages, err := parseBundle(r, unmarshal)
+	if err != nil {
+		return err
+	}
+	t.AddMessages(lang, messages)
+	return nil
+}
+
+// LoadFile reads a bundle file from the file system.
+// The format is detected from the file extension (`.json`, `.yaml`, `.yml`, `.toml` or a registered format), and
+// the language from the last dot-separated part of the file name, e.g. `fr.json`, `errors.fr-CH.yaml`.
+func (t *Translator) LoadFile(fsys fs.FS, filePath string) error {
+	format, err := t.formatOf(filePath)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	if _, err = language.Parse(name); err != nil {
+		return goapperrors.Wrapf("%w: language of %s: %w", ErrBundleInvalid, filePath, err)
+	}
+	data, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return goapperrors.Wrap(err)
+	}
+	if err = t.Load(name, bytes.NewReader(data), format); err != nil {
+		return goapperrors.Wrapf("%s: %w", filePath, err)
+	}
+	return nil
+}
+
+// LoadDir reads all bundle files of the supported formats in the directory (not recursively).
+// See LoadFile for more details.
+func (t *Translator) LoadDir(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return goapperrors.Wrap(err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, err = t.formatOf(entry.Name()); err != nil {
+			continue
+		}
+		if err = t.LoadFile(fsys, path.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Languages returns the languages having messages
+func (t *Translator) Languages() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	langs := make([]string, 0, len(t.messages))
+	for lang := range t.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Lookup returns the raw message of the key in the language
+func (t *Translator) Lookup(lang goapperrors.Language, key string) (string, bool) {
+	msg := t.lookup(languageKey(lang), key)
+	if msg == nil {
+		return "", false
+	}
+	return msg.pattern, true
+}
+
+func (t *Translator) lookup(langKey, key string) *bundleMessage {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.messages[langKey][key]
+}
+
+// Translate translates the key in the language with the params, it implements
+// goapperrors.TranslationFunc. Plural rules of the language are used to format plural arguments.
+// A *MissingKeyError is returned if there is no message for the key, an error of ErrMessageInvalid
+// is returned if the message is invalid or can't be formatted with the params.
+func (t *Translator) Translate(lang goapperrors.Language, key string, params map[string]any) (string, error) {
+	langKey := languageKey(lang)
+	msg := t.lookup(langKey, key)
+	if msg == nil {
+		return "", &MissingKeyError{Language: langKey, Key: key}
+	}
+	if msg.err != nil {
+		return "", msg.err
+	}
+	tag, err := language.Parse(langKey)
+	if err != nil {
+		tag = language.Und
+	}
+	return msg.message.Format(tag, params)
+}
+
+// ParseBundle reads a bundle in the given format (JSON, YAML or TOML). Nested tables are flattened
+// with dots as separator, all messages must be strings of valid patterns.
+func ParseBundle(r io.Reader, format Format) (map[string]string, error) {
+	unmarshal := builtinFormats[format]
+	if unmarshal == nil {
+		return nil, goapperrors.Wrapf("%w: %s", ErrFormatUnsupported, format)
+	}
+	return parseBundle(r, unmarshal)
+}
+
+// parseBundle reads a bundle with the unmarshal function, see ParseBundle
+func parseBundle(r io.Reader, unmarshal UnmarshalFunc) (map[string]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, goapperrors.Wrap(err)
+	}
+	var data map[string]any
+	if err = unmarshal(content, &data); err != nil {
+		return nil, goapperrors.Wrapf("%w: %w", ErrBundleInvalid, err)
+	}
+	messages := make(map[string]string, len(data))
+	if err := flatten(messages, "", data); err != nil {
+		return nil, err
+	}
+	for key, msg := range messages {
+		if _, err := ParseMessage(msg); err != nil {
+			return nil, goapperrors.Wrapf("%w: message %q: %w", ErrBundleInvalid, key, err)
+		}
+	}
+	return messages, nil
+}
+
+// flatten puts the nested messages into the map with keys joined by dots
+func flatten(messages map[string]string, prefix string, data map[string]any) error {
+	for k, v := range data {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch value := v.(type) {
+		case string:
+			messages[key] = value
+		case map[string]any:
+			if err := flatten(messages, key, value); err != nil {
+				return err
+			}
+		default:
+			return goapperrors.Wrapf("%w: message %q is not a string", ErrBundleInvalid, key)
+		}
+	}
+	return nil
+}
+
+// formatOf detects bundle format from the file extension
+func (t *Translator) formatOf(filePath string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimPrefix(path.Ext(filePath), ".")))
+	if format == "yml" {
+		format = FormatYAML
+	}
+	if format == "" || t.unmarshalerOf(format) == nil {
+		return "", goapperrors.Wrapf("%w: %s", ErrFormatUnsupported, filePath)
+	}
+	return format, nil
+}
+
+// languageKey returns the key of the language in the bundle map.
+// Language tags are canonicalized, so `fr-ch` and `fr-CH` are the same language.
+func languageKey(lang goapperrors.Language) string {
+	var s string
+	switch l := lang.(type) {
+	case nil:
+		return ""
+	case string:
+		s = l
+	case language.Tag:
+		return l.String()
+	default:
+		s = fmt.Sprint(lang)
+	}
+	if tag, err := language.Parse(s); err == nil {
+		return tag.String()
+	}
+	return s
+}
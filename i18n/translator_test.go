@@ -0,0 +1,198 @@
+package i18n
+
+import (
+	"embed"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	goapperrors "github.com/tiendc/go-apperrors"
+)
+
+//go:embed testdata
+var testBundleFS embed.FS
+
+// unmarshalProperties decodes `key = value` lines, used to test custom bundle formats
+func unmarshalProperties(data []byte, v any) error {
+	messages := map[string]any{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, msg, found := strings.Cut(line, "=")
+		if !found {
+			return errors.New("missing separator") //nolint:err113
+		}
+		messages[strings.TrimSpace(k)] = strings.TrimSpace(msg)
+	}
+	*v.(*map[string]any) = messages
+	return nil
+}
+
+func Test_Translator_Load(t *testing.T) {
+	t.Run("load dir", func(t *testing.T) {
+		tr := NewTranslator()
+		assert.NoError(t, tr.LoadDir(testBundleFS, "testdata"))
+		assert.Equal(t, []string{"de", "en", "fr"}, tr.Languages())
+
+		params := map[string]any{"projectID": 12, "userID": "u1"}
+		for lang, expected := range map[goapperrors.Language]string{
+			"en":            "Project 12 not found",
+			"fr":            "Projet 12 introuvable",
+			language.German: "Projekt 12 nicht gefunden",
+		} {
+			msg, err := tr.Translate(lang, "ErrProjectNotFound", params)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, msg)
+		}
+		msg, err := tr.Translate("de", "user.notFound", params)
+		assert.NoError(t, err)
+		assert.Equal(t, "Benutzer u1 nicht gefunden", msg)
+	})
+
+	t.Run("language from file name", func(t *testing.T) {
+		tr := NewTranslator()
+		fsys := fstest.MapFS{
+			"errors.fr-ch.json": {Data: []byte(`{"a": "b"}`)},
+			"errors.json":       {Data: []byte(`{"a": "b"}`)},
+		}
+		assert.NoError(t, tr.LoadFile(fsys, "errors.fr-ch.json"))
+		msg, exists := tr.Lookup("fr-CH", "a")
+		assert.True(t, exists)
+		assert.Equal(t, "b", msg)
+		assert.ErrorIs(t, tr.LoadFile(fsys, "errors.json"), ErrBundleInvalid)
+		assert.ErrorIs(t, tr.LoadFile(fsys, "errors.txt"), ErrFormatUnsupported)
+		assert.Error(t, tr.LoadFile(fsys, "en.json"))
+		assert.Error(t, tr.LoadDir(fsys, "locales"))
+	})
+
+	t.Run("registered format", func(t *testing.T) {
+		tr := NewTranslator()
+		fsys := fstest.MapFS{
+			"locales/it.properties": {Data: []byte("# Italian\nuser.notFound = Utente {userID} non trovato\n")},
+		}
+		assert.NoError(t, tr.LoadDir(fsys, "locales"))
+		assert.Equal(t, 0, len(tr.Languages()))
+
+		tr.RegisterFormat("properties", unmarshalProperties)
+		assert.NoError(t, tr.LoadDir(fsys, "locales"))
+		msg, err := tr.Translate("it", "user.notFound", map[string]any{"userID": "u1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Utente u1 non trovato", msg)
+	})
+
+	t.Run("invalid bundles", func(t *testing.T) {
+		tr := NewTranslator()
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader(`{"a": 1}`), FormatJSON), ErrBundleInvalid)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader(`{"a": `), FormatJSON), ErrBundleInvalid)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader("a: [b]"), FormatYAML), ErrBundleInvalid)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader("a = 1"), FormatTOML), ErrBundleInvalid)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader("[a]\nb = 'x'\n[a]\nc = 'y'"), FormatTOML),
+			ErrBundleInvalid)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader("a = b"), "properties"), ErrFormatUnsupported)
+		tr.RegisterFormat("properties", unmarshalProperties)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader("a"), "properties"), ErrBundleInvalid)
+		assert.Panics(t, func() { tr.RegisterFormat("", unmarshalProperties) })
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader("a: b"), "xml"), ErrFormatUnsupported)
+		assert.ErrorIs(t, tr.Load("en", strings.NewReader(`{"a": "{n, plural, one {x}}"}`), FormatJSON),
+			ErrMessageInvalid)
+		assert.NoError(t, tr.Load("en", strings.NewReader(""), FormatYAML))
+		assert.Equal(t, 0, len(tr.Languages()))
+	})
+
+	t.Run("add messages", func(t *testing.T) {
+		tr := NewTranslator()
+		tr.AddMessages("en", map[string]string{"a": "A", "b": "B"})
+		tr.AddMessages("en", map[string]string{"b": "BB"})
+		msg, _ := tr.Lookup("en", "a")
+		assert.Equal(t, "A", msg)
+		msg, _ = tr.Lookup("en", "b")
+		assert.Equal(t, "BB", msg)
+	})
+}
+
+func Test_Translator_Translate(t *testing.T) {
+	tr := NewTranslator()
+	tr.AddMessages("en", map[string]string{
+		"ErrQuota":  "Used {used} of { limit } requests, {unknown}",
+		"ErrFiles":  "{count, plural, one {# file} other {# files}} of {owner}",
+		"ErrBad":    "Unclosed {arg",
+		"ErrSimple": "Something wrong",
+	})
+
+	t.Run("interpolation", func(t *testing.T) {
+		msg, err := tr.Translate("en", "ErrQuota", map[string]any{"used": 5, "limit": 10.5})
+		assert.NoError(t, err)
+		assert.Equal(t, "Used 5 of 10.5 requests, {unknown}", msg)
+		msg, err = tr.Translate("en", "ErrSimple", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Something wrong", msg)
+	})
+
+	t.Run("message format", func(t *testing.T) {
+		msg, err := tr.Translate("en", "ErrFiles", map[string]any{"count": 1, "owner": "Bob"})
+		assert.NoError(t, err)
+		assert.Equal(t, "1 file of Bob", msg)
+		msg, err = tr.Translate("en", "ErrFiles", map[string]any{"count": 3, "owner": "Bob"})
+		assert.NoError(t, err)
+		assert.Equal(t, "3 files of Bob", msg)
+
+		_, err = tr.Translate("en", "ErrFiles", nil)
+		assert.ErrorIs(t, err, ErrMessageInvalid)
+		_, err = tr.Translate("en", "ErrBad", nil)
+		assert.ErrorIs(t, err, ErrMessageInvalid)
+		raw, exists := tr.Lookup("en", "ErrBad")
+		assert.True(t, exists)
+		assert.Equal(t, "Unclosed {arg", raw)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := tr.Translate("en-US", "ErrSimple", nil)
+		assert.ErrorIs(t, err, ErrTranslationMissing)
+		var missingErr *MissingKeyError
+		assert.True(t, errors.As(err, &missingErr))
+		assert.Equal(t, "en-US", missingErr.Language)
+		assert.Equal(t, "ErrSimple", missingErr.Key)
+		assert.Equal(t, `translation missing: key "ErrSimple" in language "en-US"`, err.Error())
+	})
+
+	t.Run("used in manager", func(t *testing.T) {
+		m := goapperrors.NewManager(&goapperrors.Config{TranslationFunc: tr.Translate})
+		errQuota := m.Create("ErrQuota", &goapperrors.ErrorConfig{Status: 429})
+		result := m.Build(m.New(errQuota).WithParam("used", 1).WithParam("limit", 2), "en")
+		assert.Equal(t, "Used 1 of 2 requests, {unknown}", result.ErrorInfo.Message)
+		assert.Equal(t, []string{"Too Many Requests"}, result.TransMissingKeys)
+
+		result = m.Build(m.New(errQuota).WithParam("used", 3).WithParam("limit", 4), "en-GB")
+		assert.Equal(t, "Used 3 of 4 requests, {unknown}", result.ErrorInfo.Message)
+		assert.Equal(t, "en", result.Language)
+
+		errFiles := m.Create("ErrFiles", &goapperrors.ErrorConfig{
+			ParamFormats: map[string]goapperrors.ParamFormat{"count": goapperrors.ParamFormatNumber},
+		})
+		result = m.Build(m.New(errFiles).WithParam("count", 1234).WithParam("owner", "Bob"), "en")
+		assert.Equal(t, "1,234 files of Bob", result.ErrorInfo.Message)
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _ = tr.Translate("en", "ErrSimple", nil)
+			}()
+			go func() {
+				defer wg.Done()
+				tr.AddMessages("fr", map[string]string{"ErrSimple": "Erreur"})
+			}()
+		}
+		wg.Wait()
+	})
+}
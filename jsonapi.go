@@ -0,0 +1,131 @@
+package goapperrors
+
+import "strconv"
+
+// JSONAPIMediaType media type of JSON:API documents
+const JSONAPIMediaType = "application/vnd.api+json"
+
+// JSONAPIDocument JSON:API document containing error objects.
+// See https://jsonapi.org/format/#error-objects
+type JSONAPIDocument struct {
+	Errors []*JSONAPIError `json:"errors"`
+}
+
+// JSONAPIError JSON:API error object
+type JSONAPIError struct {
+	ID     string              `json:"id,omitempty"`
+	Status string              `json:"status,omitempty"`
+	Code   string              `json:"code,omitempty"`
+	Title  string              `json:"title,omitempty"`
+	Detail string              `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+	Meta   map[string]any      `json:"meta,omitempty"`
+}
+
+// JSONAPIErrorSource JSON:API object referencing the source of an error
+type JSONAPIErrorSource struct {
+	// Pointer JSON pointer (RFC 6901) to the value in the request document
+	Pointer string `json:"pointer,omitempty"`
+	// Parameter name of the query parameter
+	Parameter string `json:"parameter,omitempty"`
+	// Header name of the request header
+	Header string `json:"header,omitempty"`
+}
+
+// JSONAPIConfig config used to build JSON:API error objects
+type JSONAPIConfig struct {
+	// IDFunc generates the member `id` of error objects, the member is omitted if unset
+	IDFunc func(*ErrorInfo) string
+	// SourceFunc converts `Source` of error info to a source object instead of the default conversion
+	SourceFunc func(source any) *JSONAPIErrorSource
+}
+
+// JSONAPIOption config setter for building JSON:API error objects
+type JSONAPIOption func(*JSONAPIConfig)
+
+// JSONAPIOptionIDFunc sets function generating the member `id`
+func JSONAPIOptionIDFunc(idFunc func(*ErrorInfo) string) JSONAPIOption {
+	return func(cfg *JSONAPIConfig) {
+		cfg.IDFunc = idFunc
+	}
+}
+
+// JSONAPIOptionSourceFunc sets function converting `Source` of error info to a source object
+func JSONAPIOptionSourceFunc(sourceFunc func(source any) *JSONAPIErrorSource) JSONAPIOption {
+	return func(cfg *JSONAPIConfig) {
+		cfg.SourceFunc = sourceFunc
+	}
+}
+
+// JSONAPIErrors converts the error info to a JSON:API document.
+// Inner errors of a MultiError (e.g. ValidationError) are flattened into separate error objects,
+// an inner error without status takes the one of its parent.
+// Params and the config `Extra` are put in the member `meta`.
+//
+// By default, `Source` is converted as below:
+//   - JSONAPIErrorSource: used as is
+//   - map with keys `pointer`, `parameter` or `header`: converted to the respective fields
+//   - string starting with `/`: used as JSON pointer
+//   - other strings: used as name of a top-level member, e.g. `name` becomes `/name`
+func (e *ErrorInfo) JSONAPIErrors(options ...JSONAPIOption) *JSONAPIDocument {
+	cfg := &JSONAPIConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+	doc := &JSONAPIDocument{}
+	doc.Errors = e.appendJSONAPIErrors(doc.Errors, e.Status, cfg)
+	return doc
+}
+
+// JSONAPIErrors converts the result error info to a JSON:API document
+func (r *InfoBuilderResult) JSONAPIErrors(options ...JSONAPIOption) *JSONAPIDocument {
+	return r.ErrorInfo.JSONAPIErrors(options...)
+}
+
+func (e *ErrorInfo) appendJSONAPIErrors(errs []*JSONAPIError, status int, cfg *JSONAPIConfig) []*JSONAPIError {
+	if e.Status != 0 {
+		status = e.Status
+	}
+	if len(e.InnerErrors) > 0 {
+		for _, inErr := range e.InnerErrors {
+			errs = inErr.appendJSONAPIErrors(errs, status, cfg)
+		}
+		return errs
+	}
+
+	apiErr := &JSONAPIError{
+		Code:   e.Code,
+		Title:  e.Title,
+		Detail: e.Message,
+	}
+	if status != 0 {
+		apiErr.Status = strconv.Itoa(status)
+	}
+	if cfg.IDFunc != nil {
+		apiErr.ID = cfg.IDFunc(e)
+	}
+	if e.Source != nil {
+		if cfg.SourceFunc != nil {
+			apiErr.Source = cfg.SourceFunc(e.Source)
+		} else {
+			apiErr.Source = jsonAPIErrorSource(e.Source)
+		}
+	}
+	if meta := e.extensionMembers(); len(meta) > 0 {
+		apiErr.Meta = meta
+	}
+	return append(errs, apiErr)
+}
+
+// jsonAPIErrorSource converts the source value to a source object, see ParseSource for
+// the supported values. Sources referencing path params can't be represented and are omitted.
+func jsonAPIErrorSource(source any) *JSONAPIErrorSource {
+	if s, ok := source.(*JSONAPIErrorSource); ok {
+		return s
+	}
+	s := ParseSource(source)
+	if s == nil || (s.Pointer == "" && s.Parameter == "" && s.Header == "") {
+		return nil
+	}
+	return &JSONAPIErrorSource{Pointer: s.Pointer, Parameter: s.Parameter, Header: s.Header}
+}
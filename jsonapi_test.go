@@ -0,0 +1,65 @@
+package goapperrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ErrorInfo_JSONAPIErrors(t *testing.T) {
+	t.Run("single error", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errNotFound := m.Create("ErrUserNotFound", &ErrorConfig{Status: 404, Extra: "extra"})
+		res := m.New(errNotFound).WithParam("userID", "u1").Build(LanguageEn)
+		doc := res.JSONAPIErrors(JSONAPIOptionIDFunc(func(e *ErrorInfo) string { return "id-" + e.Code }))
+
+		data, err := json.Marshal(doc)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"errors": [{
+			"id": "id-ErrUserNotFound",
+			"status": "404",
+			"code": "ErrUserNotFound",
+			"title": "Not Found",
+			"detail": "ErrUserNotFound",
+			"meta": {"userID": "u1", "extra": "extra"}
+		}]}`, string(data))
+	})
+
+	t.Run("validation error is flattened", func(t *testing.T) {
+		errInfo := &ErrorInfo{
+			Status: 400,
+			Code:   "ErrValidation",
+			InnerErrors: []*ErrorInfo{
+				{Code: "ErrRequired", Source: "name"},
+				{Code: "ErrTooLong", Source: "/data/attributes/a~b"},
+				{Status: 422, Code: "ErrInvalidSort", Source: map[string]any{"parameter": "sort"}},
+				{Code: "ErrNested", InnerErrors: []*ErrorInfo{
+					{Code: "ErrBadHeader", Source: JSONAPIErrorSource{Header: "X-Version"}},
+				}},
+				{Code: "ErrInvalidItem", Source: SourcePointer("items[3].name").WithLabel("Name")},
+				{Code: "ErrInvalidID", Source: SourcePathParam("id")},
+			},
+		}
+		doc := errInfo.JSONAPIErrors()
+		assert.Equal(t, []*JSONAPIError{
+			{Status: "400", Code: "ErrRequired", Source: &JSONAPIErrorSource{Pointer: "/name"}},
+			{Status: "400", Code: "ErrTooLong", Source: &JSONAPIErrorSource{Pointer: "/data/attributes/a~b"}},
+			{Status: "422", Code: "ErrInvalidSort", Source: &JSONAPIErrorSource{Parameter: "sort"}},
+			{Status: "400", Code: "ErrBadHeader", Source: &JSONAPIErrorSource{Header: "X-Version"}},
+			{Status: "400", Code: "ErrInvalidItem", Source: &JSONAPIErrorSource{Pointer: "/items/3/name"}},
+			{Status: "400", Code: "ErrInvalidID"},
+		}, doc.Errors)
+	})
+
+	t.Run("custom source conversion", func(t *testing.T) {
+		errInfo := &ErrorInfo{Code: "ErrRequired", Source: 3}
+		doc := errInfo.JSONAPIErrors(JSONAPIOptionSourceFunc(func(source any) *JSONAPIErrorSource {
+			return &JSONAPIErrorSource{Pointer: fmt.Sprintf("/items/%v", source)}
+		}))
+		assert.Equal(t, "/items/3", doc.Errors[0].Source.Pointer)
+		assert.Equal(t, "", doc.Errors[0].Status)
+		assert.Nil(t, (&ErrorInfo{Source: 3}).JSONAPIErrors().Errors[0].Source)
+	})
+}
@@ -0,0 +1,85 @@
+package goapperrors
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// LanguageMatcher matches the languages requested by clients against the supported languages,
+// e.g. the languages having translations. It is safe for concurrent use.
+//
+// Example:
+//
+//	matcher := NewLanguageMatcher(LanguageEn, LanguageFr, "fr-CH", LanguageDe)
+//	lang, _ := matcher.Match(r.Header.Get("Accept-Language"))
+//	result := Build(err, lang)
+type LanguageMatcher struct {
+	languages []Language
+	matcher   language.Matcher
+}
+
+// NewLanguageMatcher creates a matcher of the supported languages. The first language is the default
+// one which is returned when no supported languages match. Languages can be strings or language.Tag
+// values, a returned language is one of the given values.
+// This func panics if no languages are given or a language is not a valid BCP 47 tag.
+func NewLanguageMatcher(supported ...Language) *LanguageMatcher {
+	if len(supported) == 0 {
+		panic("language matcher requires at least one supported language")
+	}
+	tags := make([]language.Tag, 0, len(supported))
+	for _, lang := range supported {
+		tag, err := languageTag(lang)
+		if err != nil {
+			panic(fmt.Sprintf("language matcher: %v", err))
+		}
+		tags = append(tags, tag)
+	}
+	return &LanguageMatcher{
+		languages: append([]Language{}, supported...),
+		matcher:   language.NewMatcher(tags),
+	}
+}
+
+// Languages returns the supported languages
+func (m *LanguageMatcher) Languages() []Language {
+	return append([]Language{}, m.languages...)
+}
+
+// Match returns the best supported language for the value of header Accept-Language and
+// the confidence of the match. The default language is returned with confidence `language.No`
+// if the header is empty, invalid or no languages match.
+//
+// Example:
+//
+//	Accept-Language: fr-BE, de;q=0.8
+//	  gives result: "fr" with confidence `language.High` if "fr" is supported
+func (m *LanguageMatcher) Match(acceptLang string) (Language, language.Confidence) {
+	tags, _, err := language.ParseAcceptLanguage(acceptLang)
+	if err != nil {
+		return m.languages[0], language.No
+	}
+	return m.MatchTags(tags...)
+}
+
+// MatchTags returns the best supported language for the tags in priority order and
+// the confidence of the match. See Match for more details.
+func (m *LanguageMatcher) MatchTags(tags ...language.Tag) (Language, language.Confidence) {
+	if len(tags) == 0 {
+		return m.languages[0], language.No
+	}
+	_, index, confidence := m.matcher.Match(tags...)
+	return m.languages[index], confidence
+}
+
+// languageTag converts the language to a language.Tag
+func languageTag(lang Language) (language.Tag, error) {
+	if tag, ok := lang.(language.Tag); ok {
+		return tag, nil
+	}
+	tag, err := language.Parse(fmt.Sprint(lang))
+	if err != nil {
+		return language.Und, Wrap(err)
+	}
+	return tag, nil
+}
@@ -0,0 +1,66 @@
+package goapperrors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func Test_LanguageMatcher(t *testing.T) {
+	matcher := NewLanguageMatcher(LanguageEn, LanguageFr, "fr-CH", language.German, "zh-Hant")
+
+	t.Run("match header", func(t *testing.T) {
+		for header, expected := range map[string]Language{
+			"fr-CH, fr;q=0.9, en;q=0.8": "fr-CH",
+			"fr-BE":                     LanguageFr,
+			"de-AT;q=0.9, ja":           language.German,
+			"zh-TW":                     "zh-Hant",
+			"en-US":                     LanguageEn,
+		} {
+			lang, confidence := matcher.Match(header)
+			assert.Equal(t, expected, lang, header)
+			assert.NotEqual(t, language.No, confidence, header)
+		}
+
+		lang, confidence := matcher.Match("fr-CH")
+		assert.Equal(t, "fr-CH", lang)
+		assert.Equal(t, language.Exact, confidence)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		for _, header := range []string{"", "ja, ko;q=0.5", "***"} {
+			lang, confidence := matcher.Match(header)
+			assert.Equal(t, LanguageEn, lang, header)
+			assert.Equal(t, language.No, confidence, header)
+		}
+	})
+
+	t.Run("match tags", func(t *testing.T) {
+		lang, confidence := matcher.MatchTags(language.MustParse("de-CH"), language.French)
+		assert.Equal(t, language.German, lang)
+		assert.Equal(t, language.High, confidence)
+		lang, confidence = matcher.MatchTags()
+		assert.Equal(t, LanguageEn, lang)
+		assert.Equal(t, language.No, confidence)
+	})
+
+	t.Run("used in build", func(t *testing.T) {
+		m := NewManager(&Config{TranslationFunc: testTranslateOK})
+		lang, _ := matcher.Match("fr-FR, en;q=0.5")
+		result := m.Build(m.New(errTest1), lang)
+		assert.Equal(t, "(ErrTest1)-in-fr", result.ErrorInfo.Message)
+	})
+
+	t.Run("languages", func(t *testing.T) {
+		langs := matcher.Languages()
+		assert.Equal(t, []Language{LanguageEn, LanguageFr, "fr-CH", language.German, "zh-Hant"}, langs)
+		langs[0] = "ja"
+		assert.Equal(t, LanguageEn, matcher.Languages()[0])
+	})
+
+	t.Run("invalid languages", func(t *testing.T) {
+		assert.Panics(t, func() { NewLanguageMatcher() })
+		assert.Panics(t, func() { NewLanguageMatcher("en", "not a language") })
+	})
+}
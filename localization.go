@@ -1,5 +1,11 @@
 package goapperrors
 
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
 // Language represents a language.
 // Language values can be anything and can be set by client code.
 // For example, Language("string") or Language(language.Tag from "golang.org/x/text/language").
@@ -21,3 +27,54 @@ const (
 )
 
 type TranslationFunc func(lang Language, key string, params map[string]any) (string, error)
+
+// LanguageFallbacks returns the languages to try in order when a translation is missing in the language.
+// The fallbacks are the BCP 47 parents of the language followed by the default language,
+// e.g. `fr-CH` falls back to `fr` then `en`. Parents have the same kind as the language (string or
+// language.Tag), languages which are not valid tags have no parents. The language itself is not included.
+func LanguageFallbacks(lang, defaultLang Language) []Language {
+	seen := map[string]struct{}{languageKey(lang): {}}
+	var fallbacks []Language
+	add := func(fallback Language) {
+		key := languageKey(fallback)
+		if _, exists := seen[key]; exists {
+			return
+		}
+		seen[key] = struct{}{}
+		fallbacks = append(fallbacks, fallback)
+	}
+
+	switch l := lang.(type) {
+	case language.Tag:
+		for tag := l.Parent(); tag != language.Und; tag = tag.Parent() {
+			add(tag)
+		}
+	case string:
+		if tag, err := language.Parse(l); err == nil {
+			for tag = tag.Parent(); tag != language.Und; tag = tag.Parent() {
+				add(tag.String())
+			}
+		}
+	}
+	if defaultLang != nil {
+		add(defaultLang)
+	}
+	return fallbacks
+}
+
+// languageKey returns the canonical form of the language used to compare languages
+func languageKey(lang Language) string {
+	switch l := lang.(type) {
+	case nil:
+		return ""
+	case language.Tag:
+		return l.String()
+	case string:
+		if tag, err := language.Parse(l); err == nil {
+			return tag.String()
+		}
+		return l
+	default:
+		return fmt.Sprint(lang)
+	}
+}
@@ -0,0 +1,33 @@
+package goapperrors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func Test_LanguageFallbacks(t *testing.T) {
+	t.Run("string languages", func(t *testing.T) {
+		assert.Equal(t, []Language{"fr", "en"}, LanguageFallbacks("fr-CH", LanguageEn))
+		assert.Equal(t, []Language{"sr-Latn", "de"}, LanguageFallbacks("sr-Latn-RS", LanguageDe))
+		assert.Equal(t, []Language{"en"}, LanguageFallbacks("fr", LanguageEn))
+		assert.Equal(t, []Language{"en-001", "en"}, LanguageFallbacks("en-GB", "en"))
+		assert.Nil(t, LanguageFallbacks("en", LanguageEn))
+		assert.Equal(t, []Language{"en"}, LanguageFallbacks("en-us", "en-US"))
+		assert.Equal(t, []Language{"fr"}, LanguageFallbacks("fr-CH", nil))
+	})
+
+	t.Run("language tags", func(t *testing.T) {
+		assert.Equal(t, []Language{language.MustParse("pt"), LanguageEn},
+			LanguageFallbacks(language.MustParse("pt-BR"), LanguageEn))
+		assert.Equal(t, []Language{language.MustParse("de")},
+			LanguageFallbacks(language.MustParse("de-AT"), "de"))
+	})
+
+	t.Run("other languages", func(t *testing.T) {
+		assert.Equal(t, []Language{LanguageEn}, LanguageFallbacks("not a language", LanguageEn))
+		assert.Equal(t, []Language{LanguageEn}, LanguageFallbacks(123, LanguageEn))
+		assert.Equal(t, []Language{LanguageEn}, LanguageFallbacks(nil, LanguageEn))
+	})
+}
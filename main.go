@@ -1,8 +1,6 @@
 package goapperrors
 
 import (
-	"errors"
-
 	goerrors "github.com/go-errors/errors"
 )
 
@@ -12,9 +10,16 @@ func Init(cfg *Config) {
 		panic("config must not be nil")
 	}
 	cfg.setDefault()
-	globalConfig = cfg
+	defaultManager.config = cfg
+	defaultManager.registry.configure(cfg)
 	// Apply max stack depth to `go-errors` settings
-	goerrors.MaxStackDepth = globalConfig.MaxStackDepth
+	goerrors.MaxStackDepth = cfg.MaxStackDepth
+}
+
+// Default returns the manager backing the package-level functions.
+// This is useful for extensions accepting a manager to work with the global config.
+func Default() *Manager {
+	return defaultManager
 }
 
 // Add adds a global config mapping for a base error, then returns the error.
@@ -27,17 +32,15 @@ func Init(cfg *Config) {
 //		      Code: "ErrRedisKeyNotFound",
 //	 })
 func Add(err error, cfg *ErrorConfig) error {
-	if err == nil || cfg == nil {
-		panic("error and config must not be nil")
-	}
-	if cfg.Code == "" {
-		cfg.Code = err.Error()
-	}
-	if cfg.TransKey == "" {
-		cfg.TransKey = cfg.Code
-	}
-	mapError[err] = cfg
-	return err
+	return defaultManager.Add(err, cfg)
+}
+
+// TryAdd adds a global config mapping for a base error like Add, but returns an error instead of
+// panicking when the registration is rejected. The error is ErrRegistryFrozen if the mappings are
+// frozen, or wraps ErrDuplicateCode or ErrDuplicateTransKey if the registration is a duplicate
+// and `Config.DuplicateHandling` is `DuplicateError`.
+func TryAdd(err error, cfg *ErrorConfig) error {
+	return defaultManager.TryAdd(err, cfg)
 }
 
 // Create creates an error for the code with the mapping config, then returns the newly created error.
@@ -49,39 +52,78 @@ func Add(err error, cfg *ErrorConfig) error {
 //	var ErrTokenInvalid = Create("ErrTokenInvalid", &ErrorConfig{Status: http.StatusUnauthorized})
 //	var ErrNoPermission = Create("ErrNoPermission", &ErrorConfig{Status: http.StatusForbidden})
 func Create(code string, cfg *ErrorConfig) error {
-	if code == "" || cfg == nil {
-		panic("error key and config must not be nil")
-	}
-	err := errors.New(code) //nolint:err113
-	if cfg.Code == "" {
-		cfg.Code = code
-	}
-	if cfg.TransKey == "" {
-		cfg.TransKey = cfg.Code
-	}
-	mapError[err] = cfg
-	return err
+	return defaultManager.Create(code, cfg)
+}
+
+// CreateChild creates an error for the code as a child of the parent error, then returns the newly
+// created error. The child error inherits `Status`, `LogLevel`, `Title` and `Extra` from the parent
+// config unless they are set, and errors.Is(child, parent) returns `true`.
+//
+// Example:
+//
+//	var ErrNotFound = Create("ErrNotFound", &ErrorConfig{Status: http.StatusNotFound})
+//	var ErrProjectNotFound = CreateChild(ErrNotFound, "ErrProjectNotFound", &ErrorConfig{})
+func CreateChild(parent error, code string, cfg *ErrorConfig) error {
+	return defaultManager.CreateChild(parent, code, cfg)
+}
+
+// TryCreate creates an error for the code with the mapping config like Create, but returns
+// an error instead of panicking when the registration is rejected. See TryAdd for more details.
+func TryCreate(code string, cfg *ErrorConfig) (error, error) {
+	return defaultManager.TryCreate(code, cfg)
 }
 
-// Remove removes the error from the global config mappings
+// AddMatcher adds a global config mapping for all errors satisfying the match function.
+// This is useful for errors which can't be mapped by identity, such as errors with specific
+// field values. Matcher mappings have lower priority than identity and type mappings.
+// This function panics if the mappings are frozen.
+//
+// Example:
+//
+//	AddMatcher(func(err error) bool {
+//		var pgErr *pgconn.PgError
+//		return errors.As(err, &pgErr) && pgErr.Code == "23505"
+//	}, &ErrorConfig{Status: http.StatusConflict, Code: "ErrDuplicateKey"})
+func AddMatcher(match func(error) bool, cfg *ErrorConfig) {
+	defaultManager.AddMatcher(match, cfg)
+}
+
+// AddType adds a global config mapping for all errors of type T, an error matches if
+// errors.As() succeeds with a target of type T. Type mappings have lower priority than identity
+// mappings, but higher priority than matcher mappings. This function panics if the mappings are frozen.
+//
+// Example:
+//
+//	AddType[*json.SyntaxError](&ErrorConfig{Status: http.StatusBadRequest, Code: "ErrMalformedJSON"})
+func AddType[T error](cfg *ErrorConfig) {
+	AddTypeWith[T](defaultManager, cfg)
+}
+
+// Remove removes the error from the global config mappings.
+// This function panics if the mappings are frozen, use TryRemove to handle the failure.
 func Remove(err error) {
-	if err != nil {
-		delete(mapError, err)
-	}
+	defaultManager.Remove(err)
+}
+
+// TryRemove removes the error from the global config mappings.
+// This function returns ErrRegistryFrozen if the mappings are frozen.
+func TryRemove(err error) error {
+	return defaultManager.TryRemove(err)
+}
+
+// Freeze freezes the global config mappings, after that Add, Create and Remove will fail.
+// This is normally called after the application startup to make sure error codes
+// can't be mutated at runtime.
+func Freeze() {
+	defaultManager.Freeze()
+}
+
+// Entries returns all globally registered errors with their configs sorted by code
+func Entries() []RegistryEntry {
+	return defaultManager.Entries()
 }
 
 // Build builds error info
 func Build(err error, lang Language, options ...InfoBuilderOption) *InfoBuilderResult {
-	anErr := err
-	for {
-		if builder, ok := anErr.(interface {
-			Build(Language, ...InfoBuilderOption) *InfoBuilderResult
-		}); ok {
-			return builder.Build(lang, options...)
-		}
-		if anErr = errors.Unwrap(anErr); anErr == nil {
-			break
-		}
-	}
-	return New(err).Build(lang, options...)
+	return defaultManager.Build(err, lang, options...)
 }
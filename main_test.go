@@ -3,6 +3,7 @@ package goapperrors
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,14 @@ func Test_Init(t *testing.T) {
 	})
 }
 
+func Test_Default(t *testing.T) {
+	initConfig(okConfig)
+	assert.Equal(t, okConfig, Default().Config())
+	e := Create("ErrDefaultManager", &ErrorConfig{})
+	defer Remove(e)
+	assert.NotNil(t, Default().GetErrorConfig(e))
+}
+
 func Test_Add(t *testing.T) {
 	e1 := errors.New("ErrTokenInvalid")
 	e2 := Add(e1, &ErrorConfig{})
@@ -48,12 +57,63 @@ func Test_Create(t *testing.T) {
 	})
 }
 
+func Test_TryAdd(t *testing.T) {
+	e := errors.New("ErrTryAdd")
+	assert.NoError(t, TryAdd(e, &ErrorConfig{}))
+	assert.NotNil(t, GetErrorConfig(e))
+	assert.NoError(t, TryRemove(e))
+}
+
+func Test_TryCreate(t *testing.T) {
+	e, regErr := TryCreate("ErrTryCreate", &ErrorConfig{})
+	assert.NoError(t, regErr)
+	assert.Equal(t, "ErrTryCreate", e.Error())
+	assert.NoError(t, TryRemove(e))
+}
+
+type testAddTypeErr struct {
+	field string
+}
+
+func (e *testAddTypeErr) Error() string { return "invalid " + e.field }
+
+func Test_AddType(t *testing.T) {
+	m := NewManager(&Config{})
+	AddTypeWith[*testAddTypeErr](m, &ErrorConfig{Status: 422, Code: "ErrAddType"})
+	errCfg := m.GetErrorConfig(fmt.Errorf("wrapped: %w", &testAddTypeErr{field: "name"}))
+	assert.Equal(t, 422, errCfg.Status)
+	assert.Equal(t, "ErrAddType", errCfg.Code)
+	assert.Nil(t, m.GetErrorConfig(errors.New("ErrAddType")))
+	assert.Nil(t, GetErrorConfig(&testAddTypeErr{})) // the global mappings are not modified
+}
+
+func Test_AddMatcher(t *testing.T) {
+	m := NewManager(&Config{})
+	m.AddMatcher(func(err error) bool {
+		return strings.HasPrefix(err.Error(), "timeout")
+	}, &ErrorConfig{Status: 504, Code: "ErrTimeout"})
+	AddTypeWith[*testAddTypeErr](m, &ErrorConfig{Status: 400})
+
+	assert.Equal(t, 504, m.GetErrorConfig(errors.New("timeout: dial")).Status)
+	assert.Equal(t, 400, m.GetErrorConfig(&testAddTypeErr{}).Status)
+	assert.Nil(t, m.GetErrorConfig(errors.New("other")))
+
+	res := m.Build(errors.New("timeout: read"), LanguageEn)
+	assert.Equal(t, 504, res.ErrorInfo.Status)
+	assert.Equal(t, "ErrTimeout", res.ErrorInfo.Code)
+}
+
 func Test_Remove(t *testing.T) {
 	Remove(nil)
 	e := Create("ErrBadProductSKU", &ErrorConfig{})
 	assert.NotNil(t, GetErrorConfig(e))
 	Remove(e)
 	assert.Nil(t, GetErrorConfig(e))
+
+	assert.NoError(t, TryRemove(nil))
+	e = Create("ErrBadProductSKU", &ErrorConfig{})
+	assert.NoError(t, TryRemove(e))
+	assert.Nil(t, GetErrorConfig(e))
 }
 
 func Test_Build(t *testing.T) {
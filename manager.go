@@ -0,0 +1,224 @@
+package goapperrors
+
+import (
+	"errors"
+	"fmt"
+
+	goerrors "github.com/go-errors/errors"
+)
+
+// Manager carries its own config, error mappings and translation function.
+// Use a separate Manager when multiple independent components in the same binary
+// use this library and must not clobber each other's config and error codes.
+// The package-level functions are a facade over a default Manager.
+//
+// Example:
+//
+//	var errs = NewManager(&Config{TranslationFunc: translate})
+//	var ErrTokenInvalid = errs.Create("ErrTokenInvalid", &ErrorConfig{Status: http.StatusUnauthorized})
+//
+//	func handle() error {
+//		return errs.New(ErrTokenInvalid).WithDebug("token: %s", token)
+//	}
+type Manager struct {
+	config   *Config
+	registry *Registry
+}
+
+// NewManager creates a Manager with the given config.
+// Note that `MaxStackDepth` is a process-wide setting of `go-errors`, it only takes effect
+// when being set via the package function Init.
+func NewManager(cfg *Config) *Manager {
+	if cfg == nil {
+		panic("config must not be nil")
+	}
+	cfg.setDefault()
+	m := &Manager{
+		config:   cfg,
+		registry: NewRegistry(),
+	}
+	m.registry.configure(cfg)
+	return m
+}
+
+// Config returns the config of the manager
+func (m *Manager) Config() *Config {
+	return m.config
+}
+
+// Registry returns the error mapping registry of the manager
+func (m *Manager) Registry() *Registry {
+	return m.registry
+}
+
+// Add adds a config mapping for a base error, then returns the error.
+// See the package function Add for more details.
+func (m *Manager) Add(err error, cfg *ErrorConfig) error {
+	return m.registry.Add(err, cfg)
+}
+
+// TryAdd adds a config mapping for a base error, returns an error if the registration is rejected.
+// See the package function TryAdd for more details.
+func (m *Manager) TryAdd(err error, cfg *ErrorConfig) error {
+	return m.registry.TryAdd(err, cfg)
+}
+
+// Create creates an error for the code with the mapping config, then returns the newly created error.
+// See the package function Create for more details.
+func (m *Manager) Create(code string, cfg *ErrorConfig) error {
+	return m.registry.Create(code, cfg)
+}
+
+// CreateChild creates an error for the code as a child of the parent error.
+// See the package function CreateChild for more details.
+func (m *Manager) CreateChild(parent error, code string, cfg *ErrorConfig) error {
+	if cfg == nil {
+		panic("error key and config must not be nil")
+	}
+	cfg.Parent = parent
+	return m.registry.Create(code, cfg)
+}
+
+// TryCreate creates an error for the code with the mapping config, returns the newly created error
+// and an error if the registration is rejected.
+// See the package function TryCreate for more details.
+func (m *Manager) TryCreate(code string, cfg *ErrorConfig) (error, error) {
+	return m.registry.TryCreate(code, cfg)
+}
+
+// AddMatcher adds a config mapping for all errors satisfying the match function.
+// See the package function AddMatcher for more details.
+func (m *Manager) AddMatcher(match func(error) bool, cfg *ErrorConfig) {
+	m.registry.AddMatcher(match, cfg)
+}
+
+// AddTypeWith adds a config mapping in the manager for all errors of type T.
+// See the package function AddType for more details.
+func AddTypeWith[T error](m *Manager, cfg *ErrorConfig) {
+	m.registry.addTypeMatcher(func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}, cfg)
+}
+
+// Remove removes the error from the config mappings of the manager.
+// See the package function Remove for more details.
+func (m *Manager) Remove(err error) {
+	m.registry.Remove(err)
+}
+
+// TryRemove removes the error from the config mappings of the manager, returns an error
+// if the removal is rejected.
+// See the package function TryRemove for more details.
+func (m *Manager) TryRemove(err error) error {
+	return m.registry.TryRemove(err)
+}
+
+// Freeze freezes the config mappings of the manager, after that Add, Create and Remove will fail
+func (m *Manager) Freeze() {
+	m.registry.Freeze()
+}
+
+// Entries returns all registered errors with their configs sorted by code
+func (m *Manager) Entries() []RegistryEntry {
+	return m.registry.Entries()
+}
+
+// GetErrorConfig gets mapping config of an error if set
+func (m *Manager) GetErrorConfig(err error) *ErrorConfig {
+	return m.registry.Get(err)
+}
+
+// Wrap wraps an error with adding stack trace if configured
+func (m *Manager) Wrap(err error) error {
+	return m.wrap(err, 1)
+}
+
+// Wrapf wraps an error by calling fmt.Errorf and adds stack trace if configured
+func (m *Manager) Wrapf(format string, args ...any) error {
+	return m.wrap(fmt.Errorf(format, args...), 1) //nolint:err113
+}
+
+// wrap wraps an error with skipping the given number of stack frames
+func (m *Manager) wrap(err error, skip int) error {
+	if m.config.WrapFunc != nil {
+		return m.config.WrapFunc(err)
+	}
+	return goerrors.Wrap(err, skip+1)
+}
+
+// New creates an AppError containing the given error
+func (m *Manager) New(err error) AppError {
+	if err == nil {
+		return nil
+	}
+	return m.newDefaultAppError(err)
+}
+
+// NewMultiError creates a MultiError with wrapping the given errors
+func (m *Manager) NewMultiError(errs ...AppError) MultiError {
+	if len(errs) == 0 {
+		return nil
+	}
+	e := &defaultMultiError{
+		defaultAppError: m.newDefaultAppError(AppErrors(errs)),
+	}
+	// MultiError does not allow using global config mapping
+	// If you need to set custom config, sets via the field `customConfig`
+	e.disallowGlobalConfigMapping = true
+	return e
+}
+
+// NewValidationError creates a validation error for the given validation error items
+func (m *Manager) NewValidationError(errs ...AppError) ValidationError {
+	if len(errs) == 0 {
+		return nil
+	}
+	e := m.NewMultiError(errs...)
+	_ = e.WithCustomConfig(&ErrorConfig{
+		Status: m.config.DefaultValidationErrorStatus,
+		Code:   m.config.DefaultValidationErrorCode,
+	})
+	return e
+}
+
+// NewValidationErrorWithInfoBuilder creates a validation error for the given errors
+// with using the info builder for every item
+func (m *Manager) NewValidationErrorWithInfoBuilder(infoBuilder InfoBuilderFunc, errs ...error) ValidationError {
+	if len(errs) == 0 {
+		return nil
+	}
+	appErrs := make(AppErrors, 0, len(errs))
+	for _, e := range errs {
+		appErrs = append(appErrs, m.New(e).WithCustomBuilder(infoBuilder))
+	}
+	return m.NewValidationError(appErrs...)
+}
+
+// Build builds error info.
+// If there is an AppError in the error chain, it is used for building the info,
+// otherwise the error is wrapped in a new AppError of the manager.
+func (m *Manager) Build(err error, lang Language, options ...InfoBuilderOption) *InfoBuilderResult {
+	anErr := err
+	for {
+		if builder, ok := anErr.(interface {
+			Build(Language, ...InfoBuilderOption) *InfoBuilderResult
+		}); ok {
+			return builder.Build(lang, options...)
+		}
+		if anErr = errors.Unwrap(anErr); anErr == nil {
+			break
+		}
+	}
+	return m.New(err).Build(lang, options...)
+}
+
+// newDefaultAppError creates *defaultAppError
+func (m *Manager) newDefaultAppError(err error) *defaultAppError {
+	return &defaultAppError{
+		manager:     m,
+		err:         m.wrap(err, 2), //nolint:mnd
+		params:      map[string]any{},
+		transParams: map[string]string{},
+	}
+}
@@ -0,0 +1,109 @@
+package goapperrors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewManager(t *testing.T) {
+	t.Run("panic on nil input", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				assert.Fail(t, "expect panic")
+			}
+		}()
+		_ = NewManager(nil)
+	})
+
+	t.Run("default values are set", func(t *testing.T) {
+		m := NewManager(&Config{})
+		assert.Equal(t, defaultErrorStatus, m.Config().DefaultErrorStatus)
+		assert.NotNil(t, m.Registry())
+	})
+}
+
+func Test_Manager_Isolation(t *testing.T) {
+	initConfig(okConfig)
+
+	m1 := NewManager(&Config{
+		Debug:           true,
+		TranslationFunc: testTranslateOK,
+	})
+	m2 := NewManager(&Config{
+		DefaultErrorStatus: 503,
+		TranslationFunc: func(lang Language, key string, params map[string]any) (string, error) {
+			return fmt.Sprintf("m2:%s:%s", key, lang), nil
+		},
+	})
+
+	e1 := m1.Create("ErrShared", &ErrorConfig{Status: 404})
+	e2 := m2.Create("ErrShared", &ErrorConfig{LogLevel: LogLevelWarn})
+
+	assert.Equal(t, 404, m1.GetErrorConfig(e1).Status)
+	assert.Nil(t, m1.GetErrorConfig(e2))
+	assert.Nil(t, m2.GetErrorConfig(e1))
+	assert.Nil(t, GetErrorConfig(e1))
+	assert.Nil(t, GetErrorConfig(e2))
+
+	res1 := m1.Build(e1, LanguageFr)
+	assert.Equal(t, 404, res1.ErrorInfo.Status)
+	assert.Equal(t, "(ErrShared)-in-fr", res1.ErrorInfo.Message)
+	assert.Equal(t, e1.Error(), res1.ErrorInfo.Cause) // debug mode of m1
+
+	res2 := m2.Build(m2.New(e2).WithDebug("hidden"), LanguageDe)
+	assert.Equal(t, 503, res2.ErrorInfo.Status)
+	assert.Equal(t, LogLevelWarn, res2.ErrorInfo.LogLevel)
+	assert.Equal(t, "m2:ErrShared:de", res2.ErrorInfo.Message)
+	assert.Equal(t, "", res2.ErrorInfo.Debug) // non-debug mode of m2
+
+	// Building via the default manager uses the default config
+	res3 := Build(e2, LanguageEn)
+	assert.Equal(t, 500, res3.ErrorInfo.Status)
+	assert.Equal(t, "(ErrShared)-in-en", res3.ErrorInfo.Message)
+
+	// The AppError keeps the manager it was created with
+	res4 := Build(m2.New(e2), LanguageEn)
+	assert.Equal(t, 503, res4.ErrorInfo.Status)
+
+	assert.NoError(t, m1.TryRemove(e1))
+	assert.Nil(t, m1.GetErrorConfig(e1))
+}
+
+func Test_Manager_DuplicateAndFreeze(t *testing.T) {
+	m := NewManager(&Config{DuplicateHandling: DuplicateError})
+
+	e1, regErr := m.TryCreate("ErrDup", &ErrorConfig{})
+	assert.NoError(t, regErr)
+	_, regErr = m.TryCreate("ErrDup", &ErrorConfig{})
+	assert.ErrorIs(t, regErr, ErrDuplicateCode)
+	assert.ErrorIs(t, m.TryAdd(e1, &ErrorConfig{}), ErrDuplicateCode)
+	assert.NoError(t, m.TryAdd(errTest1, &ErrorConfig{}))
+
+	m.Freeze()
+	assert.True(t, m.Registry().Frozen())
+	assert.ErrorIs(t, m.TryRemove(e1), ErrRegistryFrozen)
+	assert.ErrorIs(t, m.TryAdd(errTest2, &ErrorConfig{}), ErrRegistryFrozen)
+}
+
+func Test_Manager_ValidationError(t *testing.T) {
+	m := NewManager(&Config{
+		DefaultValidationErrorStatus: 422,
+		DefaultValidationErrorCode:   "ErrInvalid",
+		TranslationFunc:              testTranslateOK,
+	})
+
+	assert.Nil(t, m.New(nil))
+	assert.Nil(t, m.NewMultiError())
+	assert.Nil(t, m.NewValidationError())
+	assert.Nil(t, m.NewValidationErrorWithInfoBuilder(nil))
+
+	vldErr := m.NewValidationErrorWithInfoBuilder(nil, errTest1, m.Wrapf("%w", errTest2))
+	res := vldErr.Build(LanguageEn)
+	assert.Equal(t, 422, res.ErrorInfo.Status)
+	assert.Equal(t, "ErrInvalid", res.ErrorInfo.Code)
+	assert.Equal(t, 2, len(res.ErrorInfo.InnerErrors))
+	assert.ErrorIs(t, vldErr, errTest2)
+	assert.ErrorIs(t, m.Wrap(errTest1), errTest1)
+}
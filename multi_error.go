@@ -21,6 +21,14 @@ func (e *defaultMultiError) Unwrap() []error {
 	return e.InnerErrors().Unwrap()
 }
 
+// Error implements `error` interface, inner errors are separated by the separator of the manager
+func (e *defaultMultiError) Error() string {
+	if inErrs := e.InnerErrors(); inErrs != nil {
+		return inErrs.join(e.manager.config.MultiErrorSeparator)
+	}
+	return e.defaultAppError.Error()
+}
+
 // InnerErrors returns all wrapped errors as AppErrors
 func (e *defaultMultiError) InnerErrors() AppErrors {
 	err := e.err
@@ -58,6 +66,18 @@ func (e *defaultMultiError) WithDebug(format string, args ...any) AppError {
 	return e
 }
 
+// WithHeader - re-defines to make sure the returning points to this error object
+func (e *defaultMultiError) WithHeader(k string, v string) AppError {
+	_ = e.defaultAppError.WithHeader(k, v)
+	return e
+}
+
+// WithParamFormat - re-defines to make sure the returning points to this error object
+func (e *defaultMultiError) WithParamFormat(k string, format ParamFormat) AppError {
+	_ = e.defaultAppError.WithParamFormat(k, format)
+	return e
+}
+
 // WithCustomConfig - re-defines to make sure the returning points to this error object
 func (e *defaultMultiError) WithCustomConfig(cfg *ErrorConfig) AppError {
 	_ = e.defaultAppError.WithCustomConfig(cfg)
@@ -100,16 +120,7 @@ func (e *defaultMultiError) Build(lang Language, options ...InfoBuilderOption) *
 
 // NewMultiError creates a MultiError with wrapping the given errors
 func NewMultiError(errs ...AppError) MultiError {
-	if len(errs) == 0 {
-		return nil
-	}
-	e := &defaultMultiError{
-		defaultAppError: newDefaultAppError(AppErrors(errs)),
-	}
-	// MultiError does not allow using global config mapping
-	// If you need to set custom config, sets via the field `customConfig`
-	e.disallowGlobalConfigMapping = true
-	return e
+	return defaultManager.NewMultiError(errs...)
 }
 
 // AsMultiError converts AppError to MultiError
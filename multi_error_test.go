@@ -44,6 +44,15 @@ func Test_MultiError_Common(t *testing.T) {
 		assert.Nil(t, me3.Cause())
 		assert.Equal(t, "", me3.Debug())
 	})
+
+	t.Run("separator of the manager", func(t *testing.T) {
+		initConfig(okConfig)
+		m := NewManager(&Config{MultiErrorSeparator: " | "})
+		me := m.NewMultiError(m.New(errTest1), m.New(errTest2))
+		assert.Equal(t, "ErrTest1 | ErrTest2", me.Error())
+		assert.Equal(t, "ErrTest1. ErrTest2", NewMultiError(New(errTest1), New(errTest2)).Error())
+		assert.Equal(t, "ErrTest1. ErrTest2", AppErrors{New(errTest1), New(errTest2)}.Error())
+	})
 }
 
 func Test_MultiError_Build(t *testing.T) {
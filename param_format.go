@@ -0,0 +1,297 @@
+package goapperrors
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// ParamFormat format hint of a param, used to localize the param value for the language
+// of the build before the message is translated
+type ParamFormat string
+
+const (
+	// ParamFormatAuto formats values by type: `time.Time` as date time, `time.Duration` as duration
+	// and `currency.Amount` as currency. Other values are kept as is.
+	ParamFormatAuto ParamFormat = ""
+	// ParamFormatRaw keeps the value as is
+	ParamFormatRaw ParamFormat = "raw"
+	// ParamFormatNumber formats numbers with the separators of the language, e.g. `1.234.567,5` in German
+	ParamFormatNumber ParamFormat = "number"
+	// ParamFormatPercent formats numbers as percentages, e.g. `0.25` as `25%`
+	ParamFormatPercent ParamFormat = "percent"
+	// ParamFormatCurrency formats `currency.Amount` values, or numbers when the currency code
+	// is given after a colon, e.g. `currency:EUR`. The amount is rounded to the digits of
+	// the currency, e.g. `1.234,50 €` in German and `€1,234.50` in English.
+	ParamFormatCurrency ParamFormat = "currency"
+	// ParamFormatDate formats the date of `time.Time` values
+	ParamFormatDate ParamFormat = "date"
+	// ParamFormatTime formats the time of `time.Time` values
+	ParamFormatTime ParamFormat = "time"
+	// ParamFormatDateTime formats the date and time of `time.Time` values
+	ParamFormatDateTime ParamFormat = "datetime"
+	// ParamFormatDuration formats `time.Duration` values, e.g. `1 h 30 min`
+	ParamFormatDuration ParamFormat = "duration"
+	// ParamFormatBytes formats numbers of bytes with binary units, e.g. `1.5 MiB`
+	ParamFormatBytes ParamFormat = "bytes"
+)
+
+// FormattedParam param value formatted for the language of the build.
+// It is printed as the formatted text, and the raw value is kept for translation functions
+// which need it, e.g. to select plural forms.
+type FormattedParam struct {
+	Value any
+	Text  string
+}
+
+// String implements fmt.Stringer
+func (p FormattedParam) String() string {
+	return p.Text
+}
+
+// dateTimeLayouts layouts of dates and times by language. This is a short hand-written list
+// rather than the CLDR data, which `golang.org/x/text` doesn't provide. Languages with region
+// fall back to their base language, other languages use the ISO layouts.
+var dateTimeLayouts = map[string][2]string{
+	"":      {"2006-01-02", "15:04"},
+	"en":    {"1/2/2006", "3:04 PM"},
+	"en-GB": {"02/01/2006", "15:04"},
+	"fr":    {"02/01/2006", "15:04"},
+	"de":    {"02.01.2006", "15:04"},
+	"es":    {"2/1/2006", "15:04"},
+	"it":    {"2/1/2006", "15:04"},
+	"pt":    {"02/01/2006", "15:04"},
+	"ru":    {"02.01.2006", "15:04"},
+	"zh":    {"2006/1/2", "15:04"},
+	"ja":    {"2006/01/02", "15:04"},
+	"ko":    {"2006. 1. 2.", "15:04"},
+	"hi":    {"2/1/2006", "3:04 PM"},
+}
+
+// currencyPatterns patterns of currency amounts by language, `¤` is replaced with the symbol
+// and `#` with the amount. Like dateTimeLayouts, this is a short hand-written list following
+// the CLDR patterns, other languages use the pattern of the empty key.
+var currencyPatterns = map[string]string{
+	"":      "¤\u00a0#",
+	"en":    "¤#",
+	"fr":    "#\u00a0¤",
+	"de":    "#\u00a0¤",
+	"de-CH": "¤\u00a0#",
+	"es":    "#\u00a0¤",
+	"it":    "#\u00a0¤",
+	"pt":    "¤\u00a0#",
+	"ru":    "#\u00a0¤",
+	"zh":    "¤#",
+	"ja":    "¤#",
+	"ko":    "¤#",
+	"hi":    "¤#",
+}
+
+// byteUnits binary units of byte sizes
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// formatParams formats the params for the language with the format hints of the params,
+// the hints in `formats` override the ones in `cfgFormats`. The given map is returned if
+// no params are formatted, otherwise a copy with FormattedParam values is returned.
+func formatParams(params map[string]any, lang Language, cfgFormats, formats map[string]ParamFormat) map[string]any {
+	var formatted map[string]any
+	var formatter *paramFormatter
+	for k, v := range params {
+		format, exists := formats[k]
+		if !exists {
+			format = cfgFormats[k]
+		}
+		if format == ParamFormatRaw {
+			continue
+		}
+		if formatter == nil {
+			formatter = newParamFormatter(lang)
+		}
+		text, ok := formatter.format(v, format)
+		if !ok {
+			continue
+		}
+		if formatted == nil {
+			formatted = make(map[string]any, len(params))
+			for pk, pv := range params {
+				formatted[pk] = pv
+			}
+		}
+		formatted[k] = FormattedParam{Value: v, Text: text}
+	}
+	if formatted == nil {
+		return params
+	}
+	return formatted
+}
+
+// paramFormatter formats param values for a language
+type paramFormatter struct {
+	tag     language.Tag
+	printer *message.Printer
+}
+
+func newParamFormatter(lang Language) *paramFormatter {
+	tag, err := languageTag(lang)
+	if err != nil {
+		tag = language.Und
+	}
+	return &paramFormatter{tag: tag, printer: message.NewPrinter(tag)}
+}
+
+// format formats the value by the format, `ok` is `false` if the value can't be formatted
+func (f *paramFormatter) format(v any, format ParamFormat) (text string, ok bool) {
+	name, arg, _ := strings.Cut(string(format), ":")
+	if format == ParamFormatAuto {
+		switch v.(type) {
+		case time.Time:
+			name = string(ParamFormatDateTime)
+		case time.Duration:
+			name = string(ParamFormatDuration)
+		case currency.Amount:
+			name = string(ParamFormatCurrency)
+		default:
+			return "", false
+		}
+	}
+
+	n, isNum := numberOf(v)
+	switch ParamFormat(name) {
+	case ParamFormatNumber:
+		if isNum {
+			return f.printer.Sprint(number.Decimal(n)), true
+		}
+	case ParamFormatPercent:
+		if isNum {
+			return f.printer.Sprint(number.Percent(n)), true
+		}
+	case ParamFormatCurrency:
+		return f.formatCurrency(v, arg)
+	case ParamFormatDate, ParamFormatTime, ParamFormatDateTime:
+		if t, isTime := v.(time.Time); isTime {
+			return f.formatTime(t, ParamFormat(name)), true
+		}
+	case ParamFormatDuration:
+		if d, isDuration := v.(time.Duration); isDuration {
+			return f.formatDuration(d), true
+		}
+	case ParamFormatBytes:
+		if isNum {
+			return f.formatBytes(n), true
+		}
+	}
+	return "", false
+}
+
+func (f *paramFormatter) formatCurrency(v any, code string) (string, bool) {
+	amount, isAmount := v.(currency.Amount)
+	if !isAmount {
+		unit, err := currency.ParseISO(code)
+		n, isNum := numberOf(v)
+		if err != nil || !isNum {
+			return "", false
+		}
+		amount = unit.Amount(n)
+	}
+	// The number is printed after the symbol by x/text, it is cut out to be placed by the pattern
+	symbol := f.printer.Sprint(currency.Symbol(amount.Currency()))
+	num := strings.TrimLeft(strings.TrimPrefix(f.printer.Sprint(currency.Symbol(amount)), symbol), " \u00a0")
+	sign := ""
+	if strings.HasPrefix(num, "-") {
+		sign, num = "-", num[1:]
+	}
+	return sign + strings.NewReplacer("¤", symbol, "#", num).Replace(localeValue(currencyPatterns, f.tag)), true
+}
+
+func (f *paramFormatter) formatTime(t time.Time, format ParamFormat) string {
+	layouts := localeValue(dateTimeLayouts, f.tag)
+	switch format { //nolint:exhaustive
+	case ParamFormatDate:
+		return t.Format(layouts[0])
+	case ParamFormatTime:
+		return t.Format(layouts[1])
+	default:
+		return t.Format(layouts[0] + " " + layouts[1])
+	}
+}
+
+// localeValue returns the value of the language in the table. Languages with region fall back
+// to their base language, then to the value of the empty key.
+func localeValue[T any](table map[string]T, tag language.Tag) T {
+	value, exists := table[tag.String()]
+	if base, confidence := tag.Base(); !exists && confidence == language.Exact {
+		if region, confidence := tag.Region(); confidence == language.Exact {
+			value, exists = table[base.String()+"-"+region.String()]
+		}
+		if !exists {
+			value, exists = table[base.String()]
+		}
+	}
+	if !exists {
+		value = table[""]
+	}
+	return value
+}
+
+// formatDuration formats the duration with days, hours, minutes and seconds,
+// or milliseconds if it is less than a second
+func (f *paramFormatter) formatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + f.formatDuration(-d)
+	}
+	if d > 0 && d < time.Second {
+		return f.printer.Sprint(number.Decimal(float64(d)/float64(time.Millisecond), number.MaxFractionDigits(3))) +
+			" ms"
+	}
+	units := []struct {
+		size   time.Duration
+		symbol string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "min"},
+	}
+	parts := make([]string, 0, len(units)+1)
+	for _, unit := range units {
+		if n := d / unit.size; n > 0 {
+			parts = append(parts, f.printer.Sprint(number.Decimal(int64(n)))+" "+unit.symbol)
+			d -= n * unit.size
+		}
+	}
+	if d > 0 || len(parts) == 0 {
+		parts = append(parts, f.printer.Sprint(number.Decimal(d.Seconds(), number.MaxFractionDigits(3)))+" s")
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatBytes formats the number of bytes, it is an int64, uint64 or float64 value from numberOf
+func (f *paramFormatter) formatBytes(n any) string {
+	size := reflect.ValueOf(n).Convert(reflect.TypeOf(float64(0))).Float()
+	unit := 0
+	for math.Abs(size) >= 1024 && unit < len(byteUnits)-1 { //nolint:mnd
+		size /= 1024
+		unit++
+	}
+	return f.printer.Sprint(number.Decimal(size, number.MaxFractionDigits(1))) + " " + byteUnits[unit]
+}
+
+// numberOf converts a value of an integer or float kind to int64, uint64 or float64
+func numberOf(v any) (any, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return nil, false
+	}
+}
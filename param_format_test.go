@@ -0,0 +1,161 @@
+package goapperrors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+func Test_paramFormatter_format(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	t.Run("numbers", func(t *testing.T) {
+		for lang, expected := range map[Language]string{
+			LanguageEn:      "1,234,567.5",
+			LanguageDe:      "1.234.567,5",
+			LanguageJa:      "1,234,567.5",
+			language.French: "1\u00a0234\u00a0567,5",
+		} {
+			text, ok := newParamFormatter(lang).format(1234567.5, ParamFormatNumber)
+			assert.True(t, ok)
+			assert.Equal(t, expected, text, lang)
+		}
+		text, _ := newParamFormatter(LanguageDe).format(int16(-1234), ParamFormatNumber)
+		assert.Equal(t, "-1.234", text)
+		text, _ = newParamFormatter(LanguageDe).format(0.256, ParamFormatPercent)
+		assert.Equal(t, "26\u00a0%", text)
+		text, _ = newParamFormatter(LanguageEn).format(uint8(1), ParamFormatPercent)
+		assert.Equal(t, "100%", text)
+	})
+
+	t.Run("currencies", func(t *testing.T) {
+		for lang, expected := range map[Language]string{
+			LanguageEn: "€1,234.50",
+			"en-GB":    "€1,234.50",
+			LanguageDe: "1.234,50\u00a0€",
+			"de-CH":    "EUR\u00a01’234.50",
+			LanguageFr: "1\u00a0234,50\u00a0€",
+			LanguageJa: "€1,234.50",
+			"nl":       "€\u00a01.234,50",
+			nil:        "€\u00a01,234.50",
+		} {
+			text, ok := newParamFormatter(lang).format(1234.5, "currency:EUR")
+			assert.True(t, ok)
+			assert.Equal(t, expected, text, lang)
+		}
+		text, ok := newParamFormatter(LanguageEn).format(currency.USD.Amount(-12), ParamFormatAuto)
+		assert.True(t, ok)
+		assert.Equal(t, "-$12.00", text)
+		text, _ = newParamFormatter(LanguageDe).format(currency.USD.Amount(-12), ParamFormatCurrency)
+		assert.Equal(t, "-12,00\u00a0$", text)
+		text, _ = newParamFormatter(LanguageJa).format(1234, "currency:JPY")
+		assert.Equal(t, "￥1,234", text)
+		_, ok = newParamFormatter(LanguageEn).format(12, ParamFormatCurrency)
+		assert.False(t, ok)
+		_, ok = newParamFormatter(LanguageEn).format(12, "currency:XXXX")
+		assert.False(t, ok)
+	})
+
+	t.Run("dates and times", func(t *testing.T) {
+		for lang, expected := range map[Language]string{
+			LanguageEn: "3/5/2024 2:30 PM",
+			"en-GB":    "05/03/2024 14:30",
+			"en-AU":    "3/5/2024 2:30 PM",
+			LanguageDe: "05.03.2024 14:30",
+			"de-CH":    "05.03.2024 14:30",
+			LanguageJa: "2024/03/05 14:30",
+			"sv":       "2024-03-05 14:30",
+			nil:        "2024-03-05 14:30",
+		} {
+			text, ok := newParamFormatter(lang).format(date, ParamFormatAuto)
+			assert.True(t, ok)
+			assert.Equal(t, expected, text, lang)
+		}
+		text, _ := newParamFormatter(LanguageFr).format(date, ParamFormatDate)
+		assert.Equal(t, "05/03/2024", text)
+		text, _ = newParamFormatter(LanguageEn).format(date, ParamFormatTime)
+		assert.Equal(t, "2:30 PM", text)
+	})
+
+	t.Run("durations", func(t *testing.T) {
+		for d, expected := range map[time.Duration]string{
+			0:                                    "0 s",
+			1500 * time.Microsecond:              "1,5 ms",
+			90 * time.Second:                     "1 min 30 s",
+			26*time.Hour + 1500*time.Millisecond: "1 d 2 h 1,5 s",
+			-2 * time.Hour:                       "-2 h",
+		} {
+			text, ok := newParamFormatter(LanguageDe).format(d, ParamFormatAuto)
+			assert.True(t, ok)
+			assert.Equal(t, expected, text, d)
+		}
+	})
+
+	t.Run("byte sizes", func(t *testing.T) {
+		for size, expected := range map[any]string{
+			512:               "512 B",
+			1536:              "1.5 KiB",
+			uint64(5) << 30:   "5 GiB",
+			float32(1 << 20):  "1 MiB",
+			int64(1234567890): "1.1 GiB",
+		} {
+			text, ok := newParamFormatter(LanguageEn).format(size, ParamFormatBytes)
+			assert.True(t, ok)
+			assert.Equal(t, expected, text, size)
+		}
+	})
+
+	t.Run("values kept as is", func(t *testing.T) {
+		f := newParamFormatter(LanguageEn)
+		for _, v := range []any{1234, 1.5, "abc", nil, []int{1}} {
+			_, ok := f.format(v, ParamFormatAuto)
+			assert.False(t, ok)
+		}
+		for format, v := range map[ParamFormat]any{
+			ParamFormatNumber:   "1234",
+			ParamFormatPercent:  true,
+			ParamFormatDate:     "2024-03-05",
+			ParamFormatDuration: 10,
+			ParamFormatBytes:    "1 KiB",
+			"unknown":           12,
+		} {
+			_, ok := f.format(v, format)
+			assert.False(t, ok, format)
+		}
+	})
+}
+
+func Test_formatParams(t *testing.T) {
+	params := map[string]any{"amount": 1234.5, "id": 12345, "size": 2048, "wait": time.Minute}
+	formatted := formatParams(params, LanguageDe,
+		map[string]ParamFormat{"amount": ParamFormatNumber, "size": ParamFormatBytes, "wait": ParamFormatDuration},
+		map[string]ParamFormat{"size": ParamFormatNumber, "wait": ParamFormatRaw})
+	assert.Equal(t, map[string]any{
+		"amount": FormattedParam{Value: 1234.5, Text: "1.234,5"},
+		"id":     12345,
+		"size":   FormattedParam{Value: 2048, Text: "2.048"},
+		"wait":   time.Minute,
+	}, formatted)
+	assert.Equal(t, 1234.5, params["amount"])
+	assert.Equal(t, "2.048", fmt.Sprint(formatted["size"]))
+
+	// Values of time.Time, time.Duration and currency.Amount are formatted without hint
+	date := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	params = map[string]any{"since": date, "price": currency.EUR.Amount(1), "wait": time.Minute}
+	formatted = formatParams(params, LanguageDe, nil, map[string]ParamFormat{"wait": ParamFormatRaw})
+	assert.Equal(t, map[string]any{
+		"since": FormattedParam{Value: date, Text: "05.03.2024 14:30"},
+		"price": FormattedParam{Value: currency.EUR.Amount(1), Text: "1,00\u00a0€"},
+		"wait":  time.Minute,
+	}, formatted)
+
+	// The same map is returned if no params are formatted
+	params = map[string]any{"id": 12345}
+	formatted = formatParams(params, LanguageDe, nil, nil)
+	formatted["x"] = 1
+	assert.Equal(t, 1, params["x"])
+}
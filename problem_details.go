@@ -0,0 +1,220 @@
+package goapperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ProblemDetailsMediaType media type of RFC 9457 problem details documents
+	ProblemDetailsMediaType = "application/problem+json"
+
+	// ProblemTypeBlank default problem type indicating no additional semantics
+	ProblemTypeBlank = "about:blank"
+)
+
+// ErrProblemDetailsInvalid is returned when a problem details document is malformed
+var ErrProblemDetailsInvalid = errors.New("problem details invalid")
+
+// problemDetailsMembers standard and reserved members of problem details documents
+var problemDetailsMembers = map[string]struct{}{
+	"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {}, "code": {}, "errors": {},
+}
+
+// ProblemDetails RFC 9457 problem details document.
+// See https://www.rfc-editor.org/rfc/rfc9457.html
+type ProblemDetails struct {
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+	// Code error code, serialized as the extension member `code`
+	Code string
+	// Errors problem details of the inner errors, serialized as the extension member `errors`
+	Errors []*ProblemDetails
+	// Extensions other extension members
+	Extensions map[string]any
+}
+
+// ProblemDetailsConfig config used to build problem details
+type ProblemDetailsConfig struct {
+	// TypeBaseURI base URI of the member `type`, the error code is appended to it.
+	// If empty, the member `type` is set to `about:blank`.
+	TypeBaseURI string
+	// Instance URI reference identifying the specific occurrence of the problem (e.g. request path)
+	Instance string
+}
+
+// ProblemDetailsOption config setter for building problem details
+type ProblemDetailsOption func(*ProblemDetailsConfig)
+
+// ProblemDetailsOptionTypeBaseURI sets base URI of the member `type`
+func ProblemDetailsOptionTypeBaseURI(typeBaseURI string) ProblemDetailsOption {
+	return func(cfg *ProblemDetailsConfig) {
+		cfg.TypeBaseURI = typeBaseURI
+	}
+}
+
+// ProblemDetailsOptionInstance sets the member `instance`
+func ProblemDetailsOptionInstance(instance string) ProblemDetailsOption {
+	return func(cfg *ProblemDetailsConfig) {
+		cfg.Instance = instance
+	}
+}
+
+// ProblemDetails converts the error info to a problem details document.
+// Params of the error become extension members, as well as the config `Extra`.
+// If `Extra` is a map, its entries are added as members, otherwise it is added as the member `extra`.
+// Params and extra entries conflicting with the standard members are ignored.
+func (e *ErrorInfo) ProblemDetails(options ...ProblemDetailsOption) *ProblemDetails {
+	cfg := &ProblemDetailsConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+	problem := e.problemDetails(cfg)
+	problem.Instance = cfg.Instance
+	return problem
+}
+
+func (e *ErrorInfo) problemDetails(cfg *ProblemDetailsConfig) *ProblemDetails {
+	problem := &ProblemDetails{
+		Type:   problemType(cfg.TypeBaseURI, e.Code),
+		Title:  e.Title,
+		Status: e.Status,
+		Detail: e.Message,
+		Code:   e.Code,
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(e.Status)
+	}
+
+	extensions := e.extensionMembers()
+	if e.Source != nil {
+		extensions["source"] = e.Source
+	}
+	for k := range problemDetailsMembers {
+		delete(extensions, k)
+	}
+	if len(extensions) > 0 {
+		problem.Extensions = extensions
+	}
+
+	for _, inErr := range e.InnerErrors {
+		problem.Errors = append(problem.Errors, inErr.problemDetails(cfg))
+	}
+	return problem
+}
+
+// ProblemDetails converts the result error info to a problem details document
+func (r *InfoBuilderResult) ProblemDetails(options ...ProblemDetailsOption) *ProblemDetails {
+	return r.ErrorInfo.ProblemDetails(options...)
+}
+
+// ErrorInfo converts the problem details document back to an error info.
+// Extension members are set as params of the error info.
+func (p *ProblemDetails) ErrorInfo() *ErrorInfo {
+	errInfo := &ErrorInfo{
+		Status:  p.Status,
+		Code:    p.Code,
+		Title:   p.Title,
+		Message: p.Detail,
+	}
+	if len(p.Extensions) > 0 {
+		errInfo.Params = make(map[string]any, len(p.Extensions))
+		for k, v := range p.Extensions {
+			errInfo.Params[k] = v
+		}
+		errInfo.Source = p.Extensions["source"]
+	}
+	for _, inProblem := range p.Errors {
+		errInfo.InnerErrors = append(errInfo.InnerErrors, inProblem.ErrorInfo())
+	}
+	return errInfo
+}
+
+// MarshalJSON implements json.Marshaler
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+len(problemDetailsMembers))
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = ProblemTypeBlank
+	}
+	setIfNotZero(members, "title", p.Title)
+	setIfNotZero(members, "status", p.Status)
+	setIfNotZero(members, "detail", p.Detail)
+	setIfNotZero(members, "instance", p.Instance)
+	setIfNotZero(members, "code", p.Code)
+	if len(p.Errors) > 0 {
+		members["errors"] = p.Errors
+	}
+	return json.Marshal(members) //nolint:wrapcheck
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Members of wrong types are reported as errors, missing `type` is assumed to be `about:blank`.
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return Wrapf("%w: %w", ErrProblemDetailsInvalid, err)
+	}
+	*p = ProblemDetails{Type: ProblemTypeBlank}
+	fields := map[string]any{
+		"type": &p.Type, "title": &p.Title, "status": &p.Status, "detail": &p.Detail,
+		"instance": &p.Instance, "code": &p.Code, "errors": &p.Errors,
+	}
+	for k, raw := range members {
+		if field, ok := fields[k]; ok {
+			if err := json.Unmarshal(raw, field); err != nil {
+				return Wrapf("%w: member %q: %w", ErrProblemDetailsInvalid, k, err)
+			}
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return Wrapf("%w: member %q: %w", ErrProblemDetailsInvalid, k, err)
+		}
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any, len(members))
+		}
+		p.Extensions[k] = v
+	}
+	return nil
+}
+
+// ParseProblemDetails reads a problem details document in JSON
+func ParseProblemDetails(r io.Reader) (*ProblemDetails, error) {
+	problem := &ProblemDetails{}
+	if err := json.NewDecoder(r).Decode(problem); err != nil {
+		if errors.Is(err, ErrProblemDetailsInvalid) {
+			return nil, err
+		}
+		return nil, Wrapf("%w: %w", ErrProblemDetailsInvalid, err)
+	}
+	return problem, nil
+}
+
+// problemType builds the member `type` from the base URI and the error code
+func problemType(baseURI, code string) string {
+	if baseURI == "" || code == "" {
+		return ProblemTypeBlank
+	}
+	if !strings.HasSuffix(baseURI, "/") {
+		baseURI += "/"
+	}
+	return baseURI + url.PathEscape(code)
+}
+
+func setIfNotZero[T comparable](members map[string]any, key string, value T) {
+	var zero T
+	if value != zero {
+		members[key] = value
+	}
+}
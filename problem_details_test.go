@@ -0,0 +1,119 @@
+package goapperrors
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ErrorInfo_ProblemDetails(t *testing.T) {
+	t.Run("single error", func(t *testing.T) {
+		m := NewManager(&Config{})
+		errNotFound := m.Create("ErrUserNotFound", &ErrorConfig{
+			Status: 404,
+			Extra:  map[string]any{"docs": "https://docs.example.com", "status": "ignored"},
+		})
+		res := m.New(errNotFound).WithParam("userID", "u1").Build(LanguageEn)
+		problem := res.ProblemDetails(
+			ProblemDetailsOptionTypeBaseURI("https://errors.example.com"),
+			ProblemDetailsOptionInstance("/users/u1"),
+		)
+
+		assert.Equal(t, "https://errors.example.com/ErrUserNotFound", problem.Type)
+		assert.Equal(t, "Not Found", problem.Title)
+		assert.Equal(t, 404, problem.Status)
+		assert.Equal(t, "ErrUserNotFound", problem.Detail)
+		assert.Equal(t, "/users/u1", problem.Instance)
+		assert.Equal(t, "ErrUserNotFound", problem.Code)
+		assert.Equal(t, map[string]any{"docs": "https://docs.example.com", "userID": "u1"}, problem.Extensions)
+
+		data, err := json.Marshal(problem)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"type": "https://errors.example.com/ErrUserNotFound",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "ErrUserNotFound",
+			"instance": "/users/u1",
+			"code": "ErrUserNotFound",
+			"docs": "https://docs.example.com",
+			"userID": "u1"
+		}`, string(data))
+	})
+
+	t.Run("non-map extra and default type", func(t *testing.T) {
+		errInfo := &ErrorInfo{Status: 400, Code: "ErrBad", Extra: 123, Debug: "dbg"}
+		problem := errInfo.ProblemDetails()
+		assert.Equal(t, ProblemTypeBlank, problem.Type)
+		assert.Equal(t, map[string]any{"extra": 123, "debug": "dbg"}, problem.Extensions)
+	})
+
+	t.Run("multi error", func(t *testing.T) {
+		initConfig(okConfig)
+		vldErr := NewValidationError(
+			New(errTest1).WithParam("field", "name"),
+			New(errTest2),
+		)
+		problem := vldErr.Build(LanguageEn).ProblemDetails(ProblemDetailsOptionTypeBaseURI("urn:problem/"))
+		assert.Equal(t, "urn:problem/ErrValidation", problem.Type)
+		assert.Equal(t, 2, len(problem.Errors))
+		assert.Equal(t, "urn:problem/ErrTest1", problem.Errors[0].Type)
+		assert.Equal(t, "(ErrTest1)-in-en", problem.Errors[0].Detail)
+		assert.Equal(t, "name", problem.Errors[0].Extensions["field"])
+		assert.Equal(t, "", problem.Errors[0].Instance)
+
+		data, err := json.Marshal(problem)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"errors":[{`)
+	})
+}
+
+func Test_ParseProblemDetails(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		problem, err := ParseProblemDetails(strings.NewReader(`{
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid input",
+			"code": "ErrValidation",
+			"traceId": "abc",
+			"errors": [{"type": "urn:ErrRequired", "code": "ErrRequired", "source": "name"}]
+		}`))
+		assert.NoError(t, err)
+		assert.Equal(t, ProblemTypeBlank, problem.Type)
+		assert.Equal(t, 400, problem.Status)
+		assert.Equal(t, "ErrValidation", problem.Code)
+		assert.Equal(t, map[string]any{"traceId": "abc"}, problem.Extensions)
+		assert.Equal(t, "urn:ErrRequired", problem.Errors[0].Type)
+
+		errInfo := problem.ErrorInfo()
+		assert.Equal(t, 400, errInfo.Status)
+		assert.Equal(t, "ErrValidation", errInfo.Code)
+		assert.Equal(t, "Invalid input", errInfo.Message)
+		assert.Equal(t, "abc", errInfo.Params["traceId"])
+		assert.Equal(t, "name", errInfo.InnerErrors[0].Source)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		problem := &ProblemDetails{
+			Type: "urn:ErrConflict", Title: "Conflict", Status: 409, Code: "ErrConflict",
+			Extensions: map[string]any{"resource": "user"},
+			Errors:     []*ProblemDetails{{Type: ProblemTypeBlank, Detail: "inner"}},
+		}
+		data, err := json.Marshal(problem)
+		assert.NoError(t, err)
+		parsed, err := ParseProblemDetails(strings.NewReader(string(data)))
+		assert.NoError(t, err)
+		assert.Equal(t, problem, parsed)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := ParseProblemDetails(strings.NewReader(`[]`))
+		assert.ErrorIs(t, err, ErrProblemDetailsInvalid)
+		_, err = ParseProblemDetails(strings.NewReader(`{"status": "400"}`))
+		assert.ErrorIs(t, err, ErrProblemDetailsInvalid)
+		_, err = ParseProblemDetails(strings.NewReader(`{`))
+		assert.ErrorIs(t, err, ErrProblemDetailsInvalid)
+	})
+}
@@ -0,0 +1,414 @@
+package goapperrors
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrRegistryFrozen is returned when modifying a frozen registry
+	ErrRegistryFrozen = errors.New("registry is frozen")
+	// ErrDuplicateCode is returned when an error or an error code is registered more than once
+	ErrDuplicateCode = errors.New("duplicate error code")
+	// ErrDuplicateTransKey is returned when a translation key is registered for more than one error
+	ErrDuplicateTransKey = errors.New("duplicate translation key")
+)
+
+// DuplicateHandling defines how a registry handles duplicate registrations.
+// A registration is considered duplicate when the error is already registered, or
+// its code or translation key is already used by another registered error.
+type DuplicateHandling int
+
+const (
+	// DuplicateAllow accepts duplicate registrations silently
+	DuplicateAllow DuplicateHandling = iota
+	// DuplicateWarn accepts duplicate registrations and reports them via `Config.WarningFunc`
+	DuplicateWarn
+	// DuplicateError rejects duplicate registrations with an error
+	DuplicateError
+	// DuplicatePanic panics on duplicate registrations
+	DuplicatePanic
+)
+
+// Registry stores mappings from errors to their configs.
+// A Registry is safe for concurrent use by multiple goroutines, so mappings can be
+// added or removed lazily (e.g. by plugins) while errors are being built.
+type Registry struct {
+	mu           sync.RWMutex
+	mapError     map[error]*ErrorConfig
+	codes        map[string][]error
+	transKeys    map[string][]error
+	typeMatchers []*errorMatcher
+	matchers     []*errorMatcher
+	frozen       bool
+
+	duplicateHandling DuplicateHandling
+	warningFunc       func(error)
+}
+
+// errorMatcher mapping config for the errors satisfying the match function
+type errorMatcher struct {
+	match func(error) bool
+	cfg   *ErrorConfig
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		mapError:  make(map[error]*ErrorConfig, 50), //nolint:mnd
+		codes:     make(map[string][]error, 50),     //nolint:mnd
+		transKeys: make(map[string][]error, 50),     //nolint:mnd
+	}
+}
+
+// configure applies the registry-related settings of the config
+func (r *Registry) configure(cfg *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.duplicateHandling = cfg.DuplicateHandling
+	r.warningFunc = cfg.WarningFunc
+}
+
+// Add adds a config mapping for a base error, then returns the error.
+// This function panics if the registration is rejected, use TryAdd to handle the failure.
+// See the package function Add for more details.
+func (r *Registry) Add(err error, cfg *ErrorConfig) error {
+	if regErr := r.TryAdd(err, cfg); regErr != nil {
+		panic(regErr)
+	}
+	return err
+}
+
+// TryAdd adds a config mapping for a base error.
+// This function returns ErrRegistryFrozen if the registry is frozen, or a duplicate error
+// if the registration is a duplicate and the registry is set to reject it.
+func (r *Registry) TryAdd(err error, cfg *ErrorConfig) error {
+	if err == nil || cfg == nil {
+		panic("error and config must not be nil")
+	}
+	if !reflect.ValueOf(err).Comparable() {
+		panic("error must be comparable, use AddType or AddMatcher for this kind of error")
+	}
+
+	warning, regErr := r.add(err, cfg)
+	if warning != nil {
+		r.warn(warning)
+	}
+	return regErr
+}
+
+// add adds the mapping with locking, the warning if there is will be returned
+// to be reported after releasing the lock. The code and translation key of the config
+// are defaulted only when the registration is accepted.
+func (r *Registry) add(err error, cfg *ErrorConfig) (warning error, regErr error) {
+	code, transKey := cfg.Code, cfg.TransKey
+	if code == "" {
+		code = err.Error()
+	}
+	if transKey == "" {
+		transKey = code
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.frozen {
+		return nil, ErrRegistryFrozen
+	}
+	if dupErr := r.checkDuplicate(err, code, transKey); dupErr != nil {
+		switch r.duplicateHandling {
+		case DuplicateAllow:
+		case DuplicateWarn:
+			warning = dupErr
+		case DuplicateError:
+			return nil, dupErr
+		case DuplicatePanic:
+			panic(dupErr)
+		}
+	}
+	cfg.Code, cfg.TransKey = code, transKey
+	r.removeIndex(err)
+	r.mapError[err] = cfg
+	r.codes[cfg.Code] = append(r.codes[cfg.Code], err)
+	r.transKeys[cfg.TransKey] = append(r.transKeys[cfg.TransKey], err)
+	return warning, nil
+}
+
+// Create creates an error for the code with the mapping config, then returns the newly created error.
+// This function panics if the registration is rejected, use TryCreate to handle the failure.
+// See the package function Create for more details.
+func (r *Registry) Create(code string, cfg *ErrorConfig) error {
+	err, regErr := r.TryCreate(code, cfg)
+	if regErr != nil {
+		panic(regErr)
+	}
+	return err
+}
+
+// TryCreate creates an error for the code with the mapping config.
+// This function returns the newly created error and the registration error if there is.
+// See TryAdd for more details.
+func (r *Registry) TryCreate(code string, cfg *ErrorConfig) (error, error) {
+	if code == "" || cfg == nil {
+		panic("error key and config must not be nil")
+	}
+	var err error
+	if cfg.Parent != nil {
+		err = &childError{code: code, parent: cfg.Parent}
+	} else {
+		err = errors.New(code) //nolint:err113
+	}
+	return err, r.TryAdd(err, cfg)
+}
+
+// Remove removes the error from the mappings.
+// This function panics if the registry is frozen, use TryRemove to handle the failure.
+func (r *Registry) Remove(err error) {
+	if regErr := r.TryRemove(err); regErr != nil {
+		panic(regErr)
+	}
+}
+
+// TryRemove removes the error from the mappings.
+// This function returns ErrRegistryFrozen if the registry is frozen.
+func (r *Registry) TryRemove(err error) error {
+	if err == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.frozen {
+		return ErrRegistryFrozen
+	}
+	r.removeIndex(err)
+	delete(r.mapError, err)
+	return nil
+}
+
+// Freeze freezes the registry, after that all modifications to the registry will fail.
+// This is normally called after the application startup to make sure error codes
+// can't be mutated at runtime.
+func (r *Registry) Freeze() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frozen = true
+}
+
+// Frozen returns `true` if the registry is frozen
+func (r *Registry) Frozen() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.frozen
+}
+
+// AddMatcher adds a config mapping for all errors satisfying the match function.
+// This is useful for errors which can't be mapped by identity, such as errors with specific
+// field values. This function panics if the registry is frozen.
+func (r *Registry) AddMatcher(match func(error) bool, cfg *ErrorConfig) {
+	r.addMatcher(&r.matchers, match, cfg)
+}
+
+// addTypeMatcher adds a config mapping for all errors of a type via the match function
+func (r *Registry) addTypeMatcher(match func(error) bool, cfg *ErrorConfig) {
+	r.addMatcher(&r.typeMatchers, match, cfg)
+}
+
+func (r *Registry) addMatcher(matchers *[]*errorMatcher, match func(error) bool, cfg *ErrorConfig) {
+	if match == nil || cfg == nil {
+		panic("match function and config must not be nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.frozen {
+		panic(ErrRegistryFrozen)
+	}
+	*matchers = append(*matchers, &errorMatcher{match: match, cfg: cfg})
+}
+
+// Get gets mapping config of an error if set.
+// Mappings are looked up in the following order:
+//   - identity mappings added via Add or Create, the error chain is unwrapped until a mapping is found
+//   - type mappings added via AddType in the order of registration
+//   - matcher mappings added via AddMatcher in the order of registration
+func (r *Registry) Get(err error) *ErrorConfig {
+	if err == nil {
+		return nil
+	}
+	r.mu.RLock()
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if cfg := r.getValue(e); cfg != nil {
+			r.mu.RUnlock()
+			return cfg
+		}
+	}
+	typeMatchers, matchers := r.typeMatchers, r.matchers
+	r.mu.RUnlock()
+
+	// Match functions are called without holding the lock as they are provided by client code
+	for _, m := range typeMatchers {
+		if m.match(err) {
+			return m.cfg
+		}
+	}
+	for _, m := range matchers {
+		if m.match(err) {
+			return m.cfg
+		}
+	}
+	return nil
+}
+
+// GetByCode gets the registered error for the code, returns `nil` if not found.
+// If the code is used by several errors (see DuplicateAllow), the last registered one is returned.
+func (r *Registry) GetByCode(code string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return lastError(r.codes[code])
+}
+
+// Resolve returns a copy of the config with unset fields filled with the ones of its ancestors
+// via the field `Parent`
+func (r *Registry) Resolve(cfg *ErrorConfig) ErrorConfig {
+	resolved := *cfg
+	parent := cfg.Parent
+	for depth := 0; parent != nil && depth < maxInheritanceDepth; depth++ {
+		parentCfg := r.Get(parent)
+		if parentCfg == nil {
+			break
+		}
+		if resolved.Status == 0 {
+			resolved.Status = parentCfg.Status
+		}
+		if resolved.GRPCCode == 0 {
+			resolved.GRPCCode = parentCfg.GRPCCode
+		}
+		if resolved.LogLevel == LogLevelNone {
+			resolved.LogLevel = parentCfg.LogLevel
+		}
+		if resolved.Title == "" {
+			resolved.Title = parentCfg.Title
+		}
+		if resolved.Extra == nil {
+			resolved.Extra = parentCfg.Extra
+		}
+		if resolved.Headers == nil {
+			resolved.Headers = parentCfg.Headers
+		}
+		if resolved.ParamFormats == nil {
+			resolved.ParamFormats = parentCfg.ParamFormats
+		}
+		parent = parentCfg.Parent
+	}
+	return resolved
+}
+
+// getValue returns the value for the error key in the map.
+// Errors of unhashable values are skipped as getting them from a map will panic.
+func (r *Registry) getValue(err error) *ErrorConfig {
+	if !reflect.ValueOf(err).Comparable() {
+		return nil
+	}
+	return r.mapError[err]
+}
+
+// checkDuplicate checks if the registration of the error duplicates an existing one
+func (r *Registry) checkDuplicate(err error, code, transKey string) error {
+	if _, exists := r.mapError[err]; exists {
+		return fmt.Errorf("%w: error %q is already registered", ErrDuplicateCode, err.Error())
+	}
+	if other := lastError(r.codes[code]); other != nil {
+		return fmt.Errorf("%w: code %q is already used by error %q", ErrDuplicateCode, code, other.Error())
+	}
+	if other := lastError(r.transKeys[transKey]); other != nil {
+		return fmt.Errorf("%w: key %q is already used by error %q", ErrDuplicateTransKey, transKey, other.Error())
+	}
+	return nil
+}
+
+// removeIndex removes the error from the code and translation key indexes.
+// Other errors registered with the same code or key (see DuplicateAllow) remain indexed.
+func (r *Registry) removeIndex(err error) {
+	cfg, exists := r.mapError[err]
+	if !exists {
+		return
+	}
+	removeIndexEntry(r.codes, cfg.Code, err)
+	removeIndexEntry(r.transKeys, cfg.TransKey, err)
+}
+
+// removeIndexEntry removes the error from the errors indexed by the key
+func removeIndexEntry(index map[string][]error, key string, err error) {
+	errs := index[key]
+	for i := range errs {
+		if errs[i] == err {
+			errs = append(errs[:i:i], errs[i+1:]...)
+			break
+		}
+	}
+	if len(errs) == 0 {
+		delete(index, key)
+		return
+	}
+	index[key] = errs
+}
+
+// lastError returns the last registered error of an index entry, or `nil` if there is none
+func lastError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[len(errs)-1]
+}
+
+// warn reports a warning via the warning function, or the standard logger if unset
+func (r *Registry) warn(err error) {
+	r.mu.RLock()
+	warningFunc := r.warningFunc
+	r.mu.RUnlock()
+	if warningFunc != nil {
+		warningFunc(err)
+		return
+	}
+	log.Printf("goapperrors: %v", err)
+}
+
+// RegistryEntry a registered error with its config
+type RegistryEntry struct {
+	Error  error
+	Config *ErrorConfig
+}
+
+// Entries returns all registered errors with their configs sorted by code.
+// Type and matcher mappings with code set are also returned with the field `Error` being `nil`.
+func (r *Registry) Entries() []RegistryEntry {
+	r.mu.RLock()
+	entries := make([]RegistryEntry, 0, len(r.mapError)+len(r.typeMatchers)+len(r.matchers))
+	for err, cfg := range r.mapError {
+		entries = append(entries, RegistryEntry{Error: err, Config: cfg})
+	}
+	for _, matchers := range [][]*errorMatcher{r.typeMatchers, r.matchers} {
+		for _, m := range matchers {
+			if m.cfg.Code != "" {
+				entries = append(entries, RegistryEntry{Config: m.cfg})
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	errString := func(err error) string {
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Config.Code != entries[j].Config.Code {
+			return entries[i].Config.Code < entries[j].Config.Code
+		}
+		return errString(entries[i].Error) < errString(entries[j].Error)
+	})
+	return entries
+}
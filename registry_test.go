@@ -0,0 +1,232 @@
+package goapperrors
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUnhashableErr struct {
+	items []string
+}
+
+func (e testUnhashableErr) Error() string { return "unhashable" }
+
+type testStatusErr struct {
+	status int
+}
+
+func (e *testStatusErr) Error() string { return fmt.Sprintf("status %d", e.status) }
+
+func Test_Registry(t *testing.T) {
+	t.Run("add, get and remove", func(t *testing.T) {
+		r := NewRegistry()
+		errCfg := &ErrorConfig{Status: 1234}
+		e1 := r.Add(errTest1, errCfg)
+		e2 := r.Create("ErrTest2", &ErrorConfig{Status: 5678})
+
+		assert.Equal(t, errTest1, e1)
+		assert.Equal(t, errCfg, r.Get(errTest1))
+		assert.Equal(t, "ErrTest1", r.Get(errTest1).Code)
+		assert.Equal(t, "ErrTest1", r.Get(errTest1).TransKey)
+		assert.Equal(t, errCfg, r.Get(fmt.Errorf("wrapped: %w", Wrap(errTest1))))
+		assert.Equal(t, 5678, r.Get(e2).Status)
+		assert.Equal(t, "ErrTest2", r.Get(e2).Code)
+		assert.Nil(t, r.Get(errTest2)) // not the same error object as e2
+		assert.Nil(t, r.Get(nil))
+
+		assert.NoError(t, r.TryRemove(nil))
+		assert.NoError(t, r.TryRemove(errTest1))
+		assert.Nil(t, r.Get(errTest1))
+		assert.NotNil(t, r.Get(e2))
+	})
+
+	t.Run("duplicate: allow", func(t *testing.T) {
+		r := NewRegistry()
+		e1 := r.Create("ErrDup", &ErrorConfig{Status: 1})
+		e2 := r.Create("ErrDup", &ErrorConfig{Status: 2})
+		assert.True(t, e1 != e2) // distinct error objects
+		assert.Equal(t, 2, r.Get(e2).Status)
+		assert.NoError(t, r.TryAdd(e1, &ErrorConfig{Status: 3}))
+		assert.Equal(t, 3, r.Get(e1).Status)
+
+		// Removing one of the errors keeps the code and key indexed for the other
+		assert.Equal(t, e2, r.GetByCode("ErrDup"))
+		r.Remove(e2)
+		assert.Equal(t, e1, r.GetByCode("ErrDup"))
+		r.configure(&Config{DuplicateHandling: DuplicateError})
+		_, regErr := r.TryCreate("ErrDup", &ErrorConfig{})
+		assert.ErrorIs(t, regErr, ErrDuplicateCode)
+		assert.ErrorIs(t, r.TryAdd(errTest1, &ErrorConfig{TransKey: "ErrDup"}), ErrDuplicateTransKey)
+		r.Remove(e1)
+		assert.Nil(t, r.GetByCode("ErrDup"))
+		assert.NoError(t, r.TryAdd(errTest1, &ErrorConfig{TransKey: "ErrDup"}))
+	})
+
+	t.Run("duplicate: warn", func(t *testing.T) {
+		r := NewRegistry()
+		var warnings []error
+		r.configure(&Config{
+			DuplicateHandling: DuplicateWarn,
+			WarningFunc:       func(err error) { warnings = append(warnings, err) },
+		})
+		e1 := r.Create("ErrDup", &ErrorConfig{})
+		_ = r.Create("ErrDup", &ErrorConfig{})
+		_ = r.Add(e1, &ErrorConfig{Code: "ErrOther"})
+		_ = r.Add(errTest1, &ErrorConfig{TransKey: "ErrOther"})
+		_ = r.Add(errTest2, &ErrorConfig{})
+		assert.Equal(t, 3, len(warnings))
+		assert.ErrorIs(t, warnings[0], ErrDuplicateCode)
+		assert.ErrorIs(t, warnings[1], ErrDuplicateCode)
+		assert.ErrorIs(t, warnings[2], ErrDuplicateTransKey)
+	})
+
+	t.Run("duplicate: error", func(t *testing.T) {
+		r := NewRegistry()
+		r.configure(&Config{DuplicateHandling: DuplicateError})
+		e1, regErr := r.TryCreate("ErrDup", &ErrorConfig{Status: 1})
+		assert.NoError(t, regErr)
+		e2, regErr := r.TryCreate("ErrDup", &ErrorConfig{Status: 2})
+		assert.ErrorIs(t, regErr, ErrDuplicateCode)
+		assert.Nil(t, r.Get(e2))
+		assert.ErrorIs(t, r.TryAdd(e1, &ErrorConfig{Code: "ErrNew"}), ErrDuplicateCode)
+		assert.Equal(t, 1, r.Get(e1).Status)
+		// Rejected configs are not modified
+		rejectedCfg := &ErrorConfig{Status: 3}
+		_, regErr = r.TryCreate("ErrDup", rejectedCfg)
+		assert.ErrorIs(t, regErr, ErrDuplicateCode)
+		assert.Equal(t, &ErrorConfig{Status: 3}, rejectedCfg)
+
+		// After removal, the code can be used again
+		assert.NoError(t, r.TryRemove(e1))
+		assert.NoError(t, r.TryAdd(e2, &ErrorConfig{}))
+
+		defer func() {
+			if r := recover(); r == nil {
+				assert.Fail(t, "expect panic")
+			}
+		}()
+		_ = r.Create("ErrDup", &ErrorConfig{})
+	})
+
+	t.Run("duplicate: panic", func(t *testing.T) {
+		r := NewRegistry()
+		r.configure(&Config{DuplicateHandling: DuplicatePanic})
+		_ = r.Create("ErrDup", &ErrorConfig{})
+		defer func() {
+			rec := recover()
+			err, _ := rec.(error)
+			assert.ErrorIs(t, err, ErrDuplicateCode)
+		}()
+		_, _ = r.TryCreate("ErrDup", &ErrorConfig{})
+	})
+
+	t.Run("freeze", func(t *testing.T) {
+		r := NewRegistry()
+		e := r.Create("ErrFrozen", &ErrorConfig{})
+		assert.False(t, r.Frozen())
+		r.Freeze()
+		assert.True(t, r.Frozen())
+
+		frozenCfg := &ErrorConfig{}
+		assert.ErrorIs(t, r.TryAdd(errTest1, frozenCfg), ErrRegistryFrozen)
+		assert.Equal(t, &ErrorConfig{}, frozenCfg)
+		_, regErr := r.TryCreate("ErrNew", &ErrorConfig{})
+		assert.ErrorIs(t, regErr, ErrRegistryFrozen)
+		assert.ErrorIs(t, r.TryRemove(e), ErrRegistryFrozen)
+		assert.Panics(t, func() { r.Remove(e) })
+		assert.NotNil(t, r.Get(e))
+
+		defer func() {
+			if r := recover(); r == nil {
+				assert.Fail(t, "expect panic")
+			}
+		}()
+		_ = r.Add(errTest1, &ErrorConfig{})
+	})
+
+	t.Run("get by code", func(t *testing.T) {
+		r := NewRegistry()
+		e := r.Create("ErrByCode", &ErrorConfig{})
+		_ = r.Add(errTest1, &ErrorConfig{Code: "ErrCustomCode"})
+		assert.Equal(t, e, r.GetByCode("ErrByCode"))
+		assert.Equal(t, errTest1, r.GetByCode("ErrCustomCode"))
+		assert.Nil(t, r.GetByCode("ErrTest1"))
+		assert.NoError(t, r.TryRemove(e))
+		assert.Nil(t, r.GetByCode("ErrByCode"))
+	})
+
+	t.Run("unhashable error", func(t *testing.T) {
+		r := NewRegistry()
+		assert.Nil(t, r.Get(testUnhashableErr{}))
+
+		defer func() {
+			if r := recover(); r == nil {
+				assert.Fail(t, "expect panic")
+			}
+		}()
+		_ = r.TryAdd(testUnhashableErr{}, &ErrorConfig{})
+	})
+
+	t.Run("type and matcher mappings", func(t *testing.T) {
+		r := NewRegistry()
+		matcherCfg := &ErrorConfig{Status: 502, Code: "ErrUpstream"}
+		r.AddMatcher(func(err error) bool {
+			var statusErr *testStatusErr
+			return errors.As(err, &statusErr) && statusErr.status >= 500
+		}, matcherCfg)
+		r.AddMatcher(func(err error) bool { return true }, &ErrorConfig{Status: 500})
+		typeCfg := &ErrorConfig{Status: 400}
+		r.addTypeMatcher(func(err error) bool {
+			var unhashableErr testUnhashableErr
+			return errors.As(err, &unhashableErr)
+		}, typeCfg)
+		identityCfg := &ErrorConfig{Status: 404}
+		_ = r.Add(errTest1, identityCfg)
+
+		assert.Equal(t, matcherCfg, r.Get(&testStatusErr{status: 503}))
+		assert.Equal(t, matcherCfg, r.Get(Wrap(&testStatusErr{status: 503})))
+		assert.Equal(t, 500, r.Get(&testStatusErr{status: 404}).Status) // catch-all matcher
+		assert.Equal(t, typeCfg, r.Get(fmt.Errorf("wrapped: %w", testUnhashableErr{})))
+		// Identity mappings take precedence over the catch-all matcher
+		assert.Equal(t, identityCfg, r.Get(fmt.Errorf("wrapped: %w", errTest1)))
+		assert.Nil(t, r.Get(nil))
+
+		entries := r.Entries()
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, "ErrTest1", entries[0].Config.Code)
+		assert.Equal(t, "ErrUpstream", entries[1].Config.Code)
+		assert.Nil(t, entries[1].Error)
+	})
+
+	t.Run("matcher: panic on nil input or frozen registry", func(t *testing.T) {
+		r := NewRegistry()
+		assert.Panics(t, func() { r.AddMatcher(nil, &ErrorConfig{}) })
+		assert.Panics(t, func() { r.AddMatcher(func(error) bool { return true }, nil) })
+		r.Freeze()
+		assert.PanicsWithValue(t, ErrRegistryFrozen, func() {
+			r.AddMatcher(func(error) bool { return true }, &ErrorConfig{})
+		})
+	})
+
+	t.Run("concurrent access", func(t *testing.T) {
+		r := NewRegistry()
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				e := r.Create(fmt.Sprintf("ErrConcurrent%d", i), &ErrorConfig{Status: i})
+				r.Remove(e)
+			}(i)
+			go func() {
+				defer wg.Done()
+				_ = r.Get(errTest1)
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,158 @@
+package goapperrors
+
+import "strings"
+
+// Source describes the location in a request causing an error.
+// It is the standard value of `ErrorInfo.Source`, which still accepts any value for compatibility.
+// Usually only one of the locations is set.
+type Source struct {
+	// Pointer JSON pointer (RFC 6901) to the value in the request body, e.g. `/items/3/name`
+	Pointer string `json:"pointer,omitempty"`
+	// Parameter name of the query parameter
+	Parameter string `json:"parameter,omitempty"`
+	// Header name of the request header
+	Header string `json:"header,omitempty"`
+	// PathParam name of the path parameter
+	PathParam string `json:"pathParam,omitempty"`
+	// Label human-readable label of the field, e.g. to be shown in forms
+	Label string `json:"label,omitempty"`
+}
+
+// SourcePointer creates a source pointing to the value in the request body.
+// The path can be a JSON pointer or a path like `items[3].name` (see JSONPointer).
+func SourcePointer(path string) *Source {
+	return &Source{Pointer: JSONPointer(path)}
+}
+
+// SourceParameter creates a source referencing the query parameter
+func SourceParameter(name string) *Source {
+	return &Source{Parameter: name}
+}
+
+// SourceHeader creates a source referencing the request header
+func SourceHeader(name string) *Source {
+	return &Source{Header: name}
+}
+
+// SourcePathParam creates a source referencing the path parameter
+func SourcePathParam(name string) *Source {
+	return &Source{PathParam: name}
+}
+
+// WithLabel sets the label of the field
+func (s *Source) WithLabel(label string) *Source {
+	s.Label = label
+	return s
+}
+
+// Field returns the referenced location: the pointer, the parameter, the header,
+// or the path param, whichever is set first
+func (s *Source) Field() string {
+	switch {
+	case s.Pointer != "":
+		return s.Pointer
+	case s.Parameter != "":
+		return s.Parameter
+	case s.Header != "":
+		return s.Header
+	default:
+		return s.PathParam
+	}
+}
+
+// String implements fmt.Stringer
+func (s *Source) String() string {
+	return s.Field()
+}
+
+// ParseSource converts a source value to a Source. Supported values are:
+//   - Source, *Source, JSONAPIErrorSource and *JSONAPIErrorSource
+//   - maps with the keys `pointer`, `parameter`, `header`, `pathParam` and `label`
+//     (e.g. a Source decoded from JSON)
+//   - strings which are converted to JSON pointers (see JSONPointer)
+//
+// Returns `nil` for other values and empty strings.
+func ParseSource(source any) *Source {
+	switch s := source.(type) {
+	case *Source:
+		return s
+	case Source:
+		return &s
+	case *JSONAPIErrorSource:
+		if s == nil {
+			return nil
+		}
+		return &Source{Pointer: s.Pointer, Parameter: s.Parameter, Header: s.Header}
+	case JSONAPIErrorSource:
+		return &Source{Pointer: s.Pointer, Parameter: s.Parameter, Header: s.Header}
+	case map[string]string:
+		return &Source{Pointer: s["pointer"], Parameter: s["parameter"], Header: s["header"],
+			PathParam: s["pathParam"], Label: s["label"]}
+	case map[string]any:
+		src := &Source{}
+		src.Pointer, _ = s["pointer"].(string)
+		src.Parameter, _ = s["parameter"].(string)
+		src.Header, _ = s["header"].(string)
+		src.PathParam, _ = s["pathParam"].(string)
+		src.Label, _ = s["label"].(string)
+		return src
+	case string:
+		if s == "" {
+			return nil
+		}
+		return SourcePointer(s)
+	}
+	return nil
+}
+
+// JSONPointer converts a path of nested fields to a JSON pointer (RFC 6901).
+// Fields are separated by dots, array indexes and keys are put in brackets (optionally quoted).
+// Paths starting with `/` are considered as JSON pointers and returned as is.
+//
+// Example:
+//
+//	JSONPointer("items[3].name")          // "/items/3/name"
+//	JSONPointer(`labels["app/name"]`)     // "/labels/app~1name"
+//	JSONPointer("/data/attributes/name")  // "/data/attributes/name"
+func JSONPointer(path string) string {
+	if path == "" || strings.HasPrefix(path, "/") {
+		return path
+	}
+	var sb strings.Builder
+	token := func(t string) {
+		sb.WriteString("/")
+		sb.WriteString(jsonPointerEscaper.Replace(t))
+	}
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			// Quoted keys may contain dots and brackets
+			closing := "]"
+			start := 1
+			if len(path) > 1 && (path[1] == '"' || path[1] == '\'') {
+				closing = path[1:2] + "]"
+				start = 2
+			}
+			end := strings.Index(path[start:], closing)
+			if end < 0 {
+				token(path[start:])
+				return sb.String()
+			}
+			token(path[start : start+end])
+			path = path[start+end+len(closing):]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			token(path[:end])
+			path = path[end:]
+		}
+	}
+	return sb.String()
+}
+
+// jsonPointerEscaper escapes a reference token of JSON pointer (RFC 6901)
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
@@ -0,0 +1,54 @@
+package goapperrors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JSONPointer(t *testing.T) {
+	assert.Equal(t, "", JSONPointer(""))
+	assert.Equal(t, "/name", JSONPointer("name"))
+	assert.Equal(t, "/items/3/name", JSONPointer("items[3].name"))
+	assert.Equal(t, "/items/0/1/tags/2", JSONPointer("items[0][1].tags[2]"))
+	assert.Equal(t, "/labels/app~1name/a~0b", JSONPointer(`labels["app/name"].a~b`))
+	assert.Equal(t, "/labels/a.b]c", JSONPointer(`labels['a.b]c']`))
+	assert.Equal(t, "/data/attributes/a~b", JSONPointer("/data/attributes/a~b"))
+	assert.Equal(t, "/items/3", JSONPointer("items[3"))
+}
+
+func Test_Source(t *testing.T) {
+	t.Run("constructors", func(t *testing.T) {
+		assert.Equal(t, &Source{Pointer: "/items/3/name", Label: "Name"}, SourcePointer("items[3].name").WithLabel("Name"))
+		assert.Equal(t, "sort", SourceParameter("sort").Field())
+		assert.Equal(t, "X-Version", SourceHeader("X-Version").Field())
+		assert.Equal(t, "projectID", SourcePathParam("projectID").String())
+		assert.Equal(t, "", (&Source{Label: "Name"}).Field())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(&ErrorInfo{Source: SourcePointer("items[3].name").WithLabel("Name")})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"source": {"pointer": "/items/3/name", "label": "Name"}}`, string(data))
+
+		errInfo := &ErrorInfo{}
+		assert.NoError(t, json.Unmarshal(data, errInfo))
+		assert.Equal(t, &Source{Pointer: "/items/3/name", Label: "Name"}, ParseSource(errInfo.Source))
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		src := SourceHeader("X-Version")
+		assert.Same(t, src, ParseSource(src))
+		assert.Equal(t, src, ParseSource(*src))
+		assert.Equal(t, &Source{Parameter: "sort"}, ParseSource(&JSONAPIErrorSource{Parameter: "sort"}))
+		assert.Equal(t, &Source{Pointer: "/a"}, ParseSource(JSONAPIErrorSource{Pointer: "/a"}))
+		assert.Equal(t, &Source{PathParam: "id", Label: "ID"},
+			ParseSource(map[string]string{"pathParam": "id", "label": "ID"}))
+		assert.Equal(t, &Source{Pointer: "/items/1"}, ParseSource("items[1]"))
+		assert.Nil(t, ParseSource(""))
+		assert.Nil(t, ParseSource(3))
+		assert.Nil(t, ParseSource(nil))
+		assert.Nil(t, ParseSource((*JSONAPIErrorSource)(nil)))
+	})
+}
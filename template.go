@@ -0,0 +1,131 @@
+package goapperrors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrTemplateInvalid is raised when a template params type is invalid
+var ErrTemplateInvalid = errors.New("template invalid")
+
+// Template is a typed definition of an error whose params are provided by a struct of type P.
+// Struct fields are turned into params when create errors, so the params are checked by the compiler.
+//
+// Fields are mapped by the tag `apperrors`:
+//
+//	type UserNotFoundParams struct {
+//		UserID string `apperrors:"userID"`       // param `userID`
+//		Role   string `apperrors:"role,trans"`   // translating param `role` (must be a string)
+//		Note   string `apperrors:"-"`            // ignored
+//		Count  int                               // param `Count`
+//	}
+//
+//	var ErrUserNotFound = Define[UserNotFoundParams]("ErrUserNotFound", &ErrorConfig{Status: 404})
+//
+//	return ErrUserNotFound.New(UserNotFoundParams{UserID: id})
+type Template[P any] struct {
+	manager *Manager
+	err     error
+	fields  []*templateField
+}
+
+// TemplateParam describes a param of a template
+type TemplateParam struct {
+	Name  string
+	Type  reflect.Type
+	Trans bool
+}
+
+type templateField struct {
+	TemplateParam
+	index int
+}
+
+// Define defines a template for the code with the mapping config in the global config mappings.
+// This function panics if P is not a struct or the registration is rejected.
+func Define[P any](code string, cfg *ErrorConfig) *Template[P] {
+	return DefineWith[P](defaultManager, code, cfg)
+}
+
+// DefineWith defines a template for the code with the mapping config in the manager.
+// This function panics if P is not a struct or the registration is rejected.
+func DefineWith[P any](m *Manager, code string, cfg *ErrorConfig) *Template[P] {
+	fields, err := parseTemplateFields(reflect.TypeOf((*P)(nil)).Elem())
+	if err != nil {
+		panic(err)
+	}
+	if cfg != nil && len(fields) > 0 {
+		cfg.Params = make(map[string]string, len(fields))
+		for _, f := range fields {
+			cfg.Params[f.Name] = f.Type.String()
+		}
+	}
+	return &Template[P]{
+		manager: m,
+		err:     m.Create(code, cfg),
+		fields:  fields,
+	}
+}
+
+// Err returns the base error of the template which can be used with errors.Is()
+func (t *Template[P]) Err() error {
+	return t.err
+}
+
+// Params returns descriptions of the template params
+func (t *Template[P]) Params() []TemplateParam {
+	params := make([]TemplateParam, 0, len(t.fields))
+	for _, f := range t.fields {
+		params = append(params, f.TemplateParam)
+	}
+	return params
+}
+
+// New creates an AppError of the template with params taken from the given struct
+func (t *Template[P]) New(p P) AppError {
+	appErr := t.manager.New(t.err)
+	v := reflect.ValueOf(p)
+	for _, f := range t.fields {
+		fieldValue := v.Field(f.index)
+		if f.Trans {
+			_ = appErr.WithTransParam(f.Name, fieldValue.String())
+		} else {
+			_ = appErr.WithParam(f.Name, fieldValue.Interface())
+		}
+	}
+	return appErr
+}
+
+// parseTemplateFields parses param fields of the struct type
+func parseTemplateFields(typ reflect.Type) ([]*templateField, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: params must be a struct, got %v", ErrTemplateInvalid, typ)
+	}
+	fields := make([]*templateField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("apperrors")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		trans := opts == "trans"
+		if trans && sf.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: translating param %s of %v must be a string",
+				ErrTemplateInvalid, sf.Name, typ)
+		}
+		fields = append(fields, &templateField{
+			TemplateParam: TemplateParam{Name: name, Type: sf.Type, Trans: trans},
+			index:         i,
+		})
+	}
+	return fields, nil
+}
@@ -0,0 +1,69 @@
+package goapperrors
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTemplateParams struct {
+	UserID  string `apperrors:"userID"`
+	Role    string `apperrors:"role,trans"`
+	Count   int
+	Ignored string `apperrors:"-"`
+	private string //nolint:unused
+}
+
+func Test_Template(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := NewManager(&Config{TranslationFunc: testTranslateOK})
+		tmpl := DefineWith[testTemplateParams](m, "ErrUserNotFound", &ErrorConfig{Status: 404})
+
+		ae := tmpl.New(testTemplateParams{UserID: "u1", Role: "admin", Count: 3, Ignored: "x"})
+		assert.ErrorIs(t, ae, tmpl.Err())
+		assert.Equal(t, map[string]any{"userID": "u1", "Count": 3}, ae.Params())
+		assert.Equal(t, map[string]string{"role": "admin"}, ae.TransParams())
+
+		res := m.Build(ae, LanguageEn)
+		assert.Equal(t, 404, res.ErrorInfo.Status)
+		assert.Equal(t, "ErrUserNotFound", res.ErrorInfo.Code)
+
+		assert.Equal(t, []TemplateParam{
+			{Name: "userID", Type: reflect.TypeOf(""), Trans: false},
+			{Name: "role", Type: reflect.TypeOf(""), Trans: true},
+			{Name: "Count", Type: reflect.TypeOf(0), Trans: false},
+		}, tmpl.Params())
+		assert.Equal(t, map[string]string{"userID": "string", "role": "string", "Count": "int"},
+			m.GetErrorConfig(tmpl.Err()).Params)
+	})
+
+	t.Run("global mapping", func(t *testing.T) {
+		tmpl := Define[struct{}]("ErrTemplateNoParams", &ErrorConfig{Status: 409})
+		defer Remove(tmpl.Err())
+
+		ae := tmpl.New(struct{}{})
+		assert.Equal(t, 409, GetErrorConfig(ae).Status)
+		assert.Equal(t, 0, len(tmpl.Params()))
+	})
+
+	t.Run("panic on non-struct params", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				assert.Fail(t, "expect panic")
+			}
+		}()
+		_ = DefineWith[string](NewManager(&Config{}), "ErrString", &ErrorConfig{})
+	})
+
+	t.Run("panic on non-string translating param", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				assert.Fail(t, "expect panic")
+			}
+		}()
+		_ = DefineWith[struct {
+			Count int `apperrors:"count,trans"`
+		}](NewManager(&Config{}), "ErrBadTrans", &ErrorConfig{})
+	})
+}
@@ -1,6 +1,8 @@
 package goapperrors
 
 import (
+	"strings"
+
 	"golang.org/x/text/language"
 )
 
@@ -37,3 +39,32 @@ func ParseAcceptLanguageAsStr(acceptLang string) ([]string, error) {
 	}
 	return langs, nil
 }
+
+// expandPlaceholders replaces placeholders `{name}` in the string with the values returned by
+// the lookup function. Unknown placeholders are kept as is.
+func expandPlaceholders(s string, lookup func(name string) (string, bool)) string {
+	if !strings.Contains(s, "{") {
+		return s
+	}
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		sb.WriteString(s[:start])
+		if v, exists := lookup(s[start+1 : end]); exists {
+			sb.WriteString(v)
+		} else {
+			sb.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
@@ -36,3 +36,16 @@ func Test_ParseRequestLangAsStr(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func Test_expandPlaceholders(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		v, exists := map[string]string{"a": "1", "b": "{a}"}[name]
+		return v, exists
+	}
+	assert.Equal(t, "", expandPlaceholders("", lookup))
+	assert.Equal(t, "no placeholders", expandPlaceholders("no placeholders", lookup))
+	assert.Equal(t, "1-{a}-1", expandPlaceholders("{a}-{b}-{a}", lookup))
+	assert.Equal(t, "x={unknown}, y=1", expandPlaceholders("x={unknown}, y={a}", lookup))
+	assert.Equal(t, "1 {a", expandPlaceholders("{a} {a", lookup))
+	assert.Equal(t, "1 }{", expandPlaceholders("{a} }{", lookup))
+}